@@ -0,0 +1,46 @@
+//go:build !race
+
+package enum
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestDecodeAllocations checks that decoding a known label does not allocate,
+// whatever the size of the enum.
+func TestDecodeAllocations(t *testing.T) {
+	for _, size := range []int{5, 200} {
+		for _, c := range decodeCases(size) {
+			t.Run(fmt.Sprintf("%s/%d", c.name, size), func(t *testing.T) {
+				var err error
+				allocs := testing.AllocsPerRun(100, func() { err = c.run() })
+				if err != nil {
+					t.Fatal(err)
+				}
+				if allocs != 0 {
+					t.Errorf("expected no allocation, got %v per run", allocs)
+				}
+			})
+		}
+	}
+}
+
+// TestEncodeAllocations checks that appending a known value to a buffer with
+// enough capacity does not allocate, whatever the size of the enum.
+func TestEncodeAllocations(t *testing.T) {
+	for _, size := range []int{5, 200} {
+		for _, c := range encodeCases(size) {
+			t.Run(fmt.Sprintf("%s/%d", c.name, size), func(t *testing.T) {
+				var err error
+				allocs := testing.AllocsPerRun(100, func() { err = c.run() })
+				if err != nil {
+					t.Fatal(err)
+				}
+				if allocs != 0 {
+					t.Errorf("expected no allocation, got %v per run", allocs)
+				}
+			})
+		}
+	}
+}
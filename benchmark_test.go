@@ -3,6 +3,8 @@ package enum
 import (
 	"fmt"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 // BenchmarkEnumString benchmarks string conversion
@@ -151,3 +153,140 @@ func BenchmarkCompareSmallVsLarge(b *testing.B) {
 		}
 	})
 }
+
+// codecCase is a decoding or encoding operation on a wrapper, expected not to allocate.
+type codecCase struct {
+	name string
+	run  func() error
+}
+
+// country is the enum of decodeCases and encodeCases. Their wrappers are not
+// registered, so that the default registry is left untouched.
+type country int
+
+// newCountryWrapper returns a wrapper for an enum of n labels.
+func newCountryWrapper(n int) Wrapper[country] {
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("country_%d", i)
+	}
+	return Wrapper[country]{Enum: NewEnum[country](labels...)}
+}
+
+// decodeCases returns every Unmarshal* and Scan operation for an enum of n labels.
+func decodeCases(n int) []codecCase {
+	label := fmt.Sprintf("country_%d", n/2)
+	w := newCountryWrapper(n)
+	numeric := w.WithRepresentation(RepresentNumber)
+
+	jsonData := []byte(`"` + label + `"`)
+	text := []byte(label)
+	binData := append([]byte{0, byte(len(label))}, label...)
+	var stringSrc any = label
+	var bytesSrc any = text
+	var intSrc any = int64(n / 2)
+	yamlNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: label}
+
+	return []codecCase{
+		{"UnmarshalJSON", func() error { return w.UnmarshalJSON(jsonData) }},
+		{"UnmarshalYAML", func() error { return w.UnmarshalYAML(yamlNode) }},
+		{"UnmarshalText", func() error { return w.UnmarshalText(text) }},
+		{"UnmarshalBinary", func() error { return w.UnmarshalBinary(binData) }},
+		{"ScanString", func() error { return w.Scan(stringSrc) }},
+		{"ScanBytes", func() error { return w.Scan(bytesSrc) }},
+		{"ScanNumber", func() error { return numeric.Scan(intSrc) }},
+	}
+}
+
+// BenchmarkDecode benchmarks every decoder on small and large enums.
+// Decoding does not allocate: see TestDecodeAllocations.
+func BenchmarkDecode(b *testing.B) {
+	for _, size := range []int{5, 200} {
+		for _, c := range decodeCases(size) {
+			b.Run(fmt.Sprintf("%s/%d", c.name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if err := c.run(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// encodeCases returns every Append* operation for an enum of n labels,
+// appending to a reused buffer.
+func encodeCases(n int) []codecCase {
+	w := newCountryWrapper(n)
+	w.Set(country(n / 2))
+	numeric := w.WithRepresentation(RepresentNumber)
+	compact := w.WithBinaryEncoding(BinaryVarint)
+	buf := make([]byte, 0, 64)
+
+	appendTo := func(appendFn func([]byte) ([]byte, error)) func() error {
+		return func() error {
+			var err error
+			buf, err = appendFn(buf[:0])
+			return err
+		}
+	}
+	return []codecCase{
+		{"AppendJSON", appendTo(w.AppendJSON)},
+		{"AppendJSONNumber", appendTo(numeric.AppendJSON)},
+		{"AppendText", appendTo(w.AppendText)},
+		{"AppendBinary", appendTo(w.AppendBinary)},
+		{"AppendBinaryVarint", appendTo(compact.AppendBinary)},
+	}
+}
+
+// BenchmarkEncode benchmarks every appender on small and large enums.
+// Appending to a buffer with enough capacity does not allocate: see TestEncodeAllocations.
+func BenchmarkEncode(b *testing.B) {
+	for _, size := range []int{5, 200} {
+		for _, c := range encodeCases(size) {
+			b.Run(fmt.Sprintf("%s/%d", c.name, size), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if err := c.run(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkEnumLookupStrategies benchmarks FromString and FromBytes for each lookup strategy
+func BenchmarkEnumLookupStrategies(b *testing.B) {
+	many := make([]string, 200)
+	for i := range many {
+		many[i] = fmt.Sprintf("country_%d", i)
+	}
+	sets := map[string][]string{
+		"Linear": {"low", "medium", "high"},
+		"Length": {"pending", "active", "shipped", "cancelled", "refunded", "lost", "on_hold", "awaiting_payment"},
+		"Map":    many,
+	}
+
+	for _, name := range []string{"Linear", "Length", "Map"} {
+		labels := sets[name]
+		enum := NewEnum[int](labels...)
+		raw := make([][]byte, len(labels))
+		for i, label := range labels {
+			raw[i] = []byte(label)
+		}
+
+		b.Run(name+"/FromString", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = enum.FromString(labels[i%len(labels)])
+			}
+		})
+		b.Run(name+"/FromBytes", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = enum.FromBytes(raw[i%len(raw)])
+			}
+		})
+	}
+}
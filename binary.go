@@ -0,0 +1,23 @@
+package enum
+
+import "github.com/gmllt/enum/internal"
+
+// BinaryEncoding selects how a Wrapper writes its value with MarshalBinary.
+// UnmarshalBinary reads every encoding, so stored data can be migrated.
+type BinaryEncoding = internal.BinaryEncoding
+
+const (
+	// BinaryLabel writes the label prefixed by its length on 2 bytes,
+	// such as 10 bytes for "critical". This is the default.
+	BinaryLabel = internal.BinaryLabel
+
+	// BinaryVarint writes a marker byte followed by the value as a varint,
+	// such as 2 bytes for a value below 64.
+	BinaryVarint = internal.BinaryVarint
+
+	// BinaryVarintFingerprint is BinaryVarint with a fingerprint byte of the
+	// enum members, so data written by a different version of the enum is
+	// rejected with ErrBinaryFingerprintMismatch instead of decoding to the
+	// wrong member.
+	BinaryVarintFingerprint = internal.BinaryVarintFingerprint
+)
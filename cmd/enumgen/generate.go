@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"text/template"
+)
+
+// Generate renders the Go source for a spec.
+func Generate(spec *Spec) ([]byte, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, spec); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+var fileTemplate = template.Must(template.New("enum").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+}).Parse(`// Code generated by enumgen; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+
+	"github.com/gmllt/enum"
+)
+{{if .Underlying}}
+// {{.Type}} is an enumeration generated by enumgen.
+type {{.Type}} {{.Underlying}}
+
+const (
+{{- range .Members}}
+	{{.Name}} {{$.Type}} = {{.Value}}
+{{- end}}
+)
+{{end}}
+// {{.Var}} is the enum definition of {{.Type}}.
+var {{.Var}} = enum.NewEnumFromMembers(
+{{- range .Members}}
+	enum.Member[{{$.Type}}]{Label: {{quote .Label}}, Value: {{.Name}}},
+{{- end}}
+)
+
+func init() {
+	if err := enum.RegisterEnum({{.Var}}, enum.ConflictError); err != nil {
+		panic(err)
+	}
+}
+
+// String returns the label of v.
+func (v {{.Type}}) String() string {
+	return {{.Var}}.String(v)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v {{.Type}}) MarshalJSON() ([]byte, error) {
+	return enum.Wrapper[{{.Type}}]{Enum: {{.Var}}, Current: v}.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *{{.Type}}) UnmarshalJSON(data []byte) error {
+	w := enum.Wrapper[{{.Type}}]{Enum: {{.Var}}}
+	if err := w.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*v = w.Current
+	return nil
+}
+
+// Value implements driver.Valuer for SQL integration.
+func (v {{.Type}}) Value() (driver.Value, error) {
+	return enum.Wrapper[{{.Type}}]{Enum: {{.Var}}, Current: v}.Value()
+}
+
+// Scan implements sql.Scanner for SQL integration.
+func (v *{{.Type}}) Scan(src any) error {
+	w := enum.Wrapper[{{.Type}}]{Enum: {{.Var}}}
+	if err := w.Scan(src); err != nil {
+		return err
+	}
+	*v = w.Current
+	return nil
+}
+`))
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// TestApplyTransform tests label derivation from constant names
+func TestApplyTransform(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		transform string
+		expected  string
+	}{
+		{name: "none", input: "InProgress", transform: TransformNone, expected: "InProgress"},
+		{name: "lower", input: "InProgress", transform: TransformLower, expected: "inprogress"},
+		{name: "snake", input: "InProgress", transform: TransformSnake, expected: "in_progress"},
+		{name: "kebab", input: "InProgress", transform: TransformKebab, expected: "in-progress"},
+		{name: "acronym", input: "HTTPError", transform: TransformSnake, expected: "http_error"},
+		{name: "trailing acronym", input: "ServerURL", transform: TransformSnake, expected: "server_url"},
+		{name: "underscores", input: "Not_Found", transform: TransformKebab, expected: "not-found"},
+		{name: "digits", input: "Level2", transform: TransformSnake, expected: "level2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ApplyTransform(tt.input, tt.transform)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := ApplyTransform("Pending", "shout"); err == nil {
+		t.Error("expected error for unknown transform")
+	}
+}
+
+// TestSpecValidate tests spec validation
+func TestSpecValidate(t *testing.T) {
+	valid := func() *Spec {
+		return &Spec{
+			Package: "orders",
+			Type:    "Status",
+			Members: []MemberSpec{
+				{Name: "Pending", Label: "pending", Value: int64Ptr(0)},
+				{Name: "Active", Label: "active", Value: int64Ptr(1)},
+			},
+		}
+	}
+
+	spec := valid()
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Var != "StatusEnum" {
+		t.Errorf("expected default var %q, got %q", "StatusEnum", spec.Var)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Spec)
+	}{
+		{name: "invalid package", modify: func(s *Spec) { s.Package = "my-pkg" }},
+		{name: "invalid type", modify: func(s *Spec) { s.Type = "" }},
+		{name: "no members", modify: func(s *Spec) { s.Members = nil }},
+		{name: "duplicate label", modify: func(s *Spec) { s.Members[1].Label = "pending" }},
+		{name: "duplicate value", modify: func(s *Spec) { s.Members[1].Value = int64Ptr(0) }},
+		{name: "duplicate name", modify: func(s *Spec) { s.Members[1].Name = "Pending" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := valid()
+			tt.modify(spec)
+			if err := spec.Validate(); err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+}
+
+// TestLoadSpecFile tests reading a JSON spec with default values
+func TestLoadSpecFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "level.json")
+	content := `{"package": "levels", "type": "Level", "members": [
+		{"name": "LevelLow", "label": "low"},
+		{"name": "LevelHigh", "label": "high", "value": 10}
+	]}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := LoadSpecFile(path)
+	if err != nil {
+		t.Fatalf("LoadSpecFile failed: %v", err)
+	}
+
+	if spec.Underlying != "int" {
+		t.Errorf("expected default underlying type int, got %q", spec.Underlying)
+	}
+	if *spec.Members[0].Value != 0 || *spec.Members[1].Value != 10 {
+		t.Errorf("unexpected values %d and %d", *spec.Members[0].Value, *spec.Members[1].Value)
+	}
+}
+
+// TestLoadPackage tests reading constants from Go source
+func TestLoadPackage(t *testing.T) {
+	dir := t.TempDir()
+	src := `package orders
+
+type Status int
+
+const (
+	StatusPending Status = iota
+	StatusInProgress
+	_
+	StatusDone = StatusInProgress + 5
+)
+
+const StatusCount = 3
+
+type Other int
+
+const OtherValue Other = 1
+`
+	if err := os.WriteFile(filepath.Join(dir, "status.go"), []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// A stale generated file must be ignored
+	if err := os.WriteFile(filepath.Join(dir, "status_enum.go"), []byte("package orders\n\nvar broken = StatusEnum\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := LoadPackage(dir, "Status", LoadOptions{Exclude: "status_enum.go"})
+	if err != nil {
+		t.Fatalf("LoadPackage failed: %v", err)
+	}
+
+	if spec.Package != "orders" {
+		t.Errorf("expected package orders, got %q", spec.Package)
+	}
+
+	var (
+		names  []string
+		labels []string
+		values []int64
+	)
+	for _, m := range spec.Members {
+		names = append(names, m.Name)
+		labels = append(labels, m.Label)
+		values = append(values, *m.Value)
+	}
+
+	if expected := []string{"StatusPending", "StatusInProgress", "StatusDone"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected names %v, got %v", expected, names)
+	}
+	if expected := []string{"pending", "in_progress", "done"}; !reflect.DeepEqual(labels, expected) {
+		t.Errorf("expected labels %v, got %v", expected, labels)
+	}
+	if expected := []int64{0, 1, 6}; !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected values %v, got %v", expected, values)
+	}
+
+	if _, err := LoadPackage(dir, "Missing", LoadOptions{}); err == nil {
+		t.Error("expected error for unknown type")
+	}
+}
+
+// TestGenerate tests the generated source
+func TestGenerate(t *testing.T) {
+	spec := &Spec{
+		Package:    "orders",
+		Type:       "Status",
+		Underlying: "uint8",
+		Members: []MemberSpec{
+			{Name: "Pending", Label: "pending", Value: int64Ptr(0)},
+			{Name: "Active", Label: "active", Value: int64Ptr(1)},
+		},
+	}
+
+	src, err := Generate(spec)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "status_enum.go", src, 0); err != nil {
+		t.Fatalf("generated code does not parse: %v", err)
+	}
+
+	for _, want := range []string{
+		"// Code generated by enumgen; DO NOT EDIT.",
+		"type Status uint8",
+		"Active  Status = 1",
+		`enum.Member[Status]{Label: "pending", Value: Pending}`,
+		"enum.RegisterEnum(StatusEnum, enum.ConflictError)",
+		"func (v Status) String() string",
+		"func (v Status) MarshalJSON() ([]byte, error)",
+		"func (v *Status) UnmarshalJSON(data []byte) error",
+		"func (v Status) Value() (driver.Value, error)",
+		"func (v *Status) Scan(src any) error",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, src)
+		}
+	}
+
+	// Constants are not redeclared when they come from the package
+	spec.Underlying = ""
+	src, err = Generate(spec)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if strings.Contains(string(src), "type Status") || strings.Contains(string(src), "const (") {
+		t.Errorf("generated code should not declare the type or constants:\n%s", src)
+	}
+}
+
+// TestRun tests the command line end to end
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	src := "package colors\n\ntype Color int\n\nconst (\n\tColorRed Color = iota\n\tColorDarkBlue\n)\n"
+	if err := os.WriteFile(filepath.Join(dir, "color.go"), []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr strings.Builder
+	if err := run([]string{"-type=Color", "-dir=" + dir, "-transform=kebab"}, &stderr); err != nil {
+		t.Fatalf("run failed: %v (%s)", err, stderr.String())
+	}
+
+	out, err := os.ReadFile(filepath.Join(dir, "color_enum.go"))
+	if err != nil {
+		t.Fatalf("output file not written: %v", err)
+	}
+	if !strings.Contains(string(out), `enum.Member[Color]{Label: "dark-blue", Value: ColorDarkBlue}`) {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	if err := run([]string{"-type=Color", "-spec=x.json"}, &stderr); err == nil {
+		t.Error("expected error for -type together with -spec")
+	}
+	if err := run(nil, &stderr); err == nil {
+		t.Error("expected error without -type or -spec")
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LoadOptions controls how labels are derived from constant names.
+type LoadOptions struct {
+	// TrimPrefix is removed from constant names before the transform.
+	// It defaults to the type name.
+	TrimPrefix string
+	// Transform is one of the Transform* constants.
+	Transform string
+	// Exclude is a file name skipped while parsing, usually the previous output.
+	Exclude string
+}
+
+// LoadPackage reads the constants of typeName from the Go package in dir.
+// Constants are returned in source order, and their values are evaluated by
+// the type checker, so iota and constant expressions are supported.
+func LoadPackage(dir, typeName string, opts LoadOptions) (*Spec, error) {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, dir, opts.Exclude)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		// Keep going on errors: the previous generated file is excluded,
+		// so references to the enum variable may be unresolved.
+		Error: func(error) {},
+	}
+	info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+	pkg, _ := conf.Check(files[0].Name.Name, fset, files, info)
+
+	obj := pkg.Scope().Lookup(typeName)
+	if obj == nil {
+		return nil, fmt.Errorf("type %s not found in %s", typeName, dir)
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a named type", typeName)
+	}
+	if basic, ok := named.Underlying().(*types.Basic); !ok || basic.Info()&types.IsInteger == 0 {
+		return nil, fmt.Errorf("type %s must have an integer underlying type", typeName)
+	}
+
+	prefix := opts.TrimPrefix
+	if prefix == "" {
+		prefix = typeName
+	}
+	transform := opts.Transform
+	if transform == "" {
+		transform = TransformSnake
+	}
+
+	var consts []*types.Const
+	for ident, def := range info.Defs {
+		c, ok := def.(*types.Const)
+		if !ok || ident.Name == "_" || !types.Identical(c.Type(), named) || c.Parent() != pkg.Scope() {
+			continue
+		}
+		consts = append(consts, c)
+	}
+	if len(consts) == 0 {
+		return nil, fmt.Errorf("no constants of type %s found in %s", typeName, dir)
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+	spec := &Spec{
+		Package: pkg.Name(),
+		Type:    typeName,
+		Members: make([]MemberSpec, 0, len(consts)),
+	}
+	for _, c := range consts {
+		value, exact := constant.Int64Val(c.Val())
+		if !exact {
+			return nil, fmt.Errorf("constant %s does not fit in int64", c.Name())
+		}
+		label, err := ApplyTransform(strings.TrimPrefix(c.Name(), prefix), transform)
+		if err != nil {
+			return nil, err
+		}
+		spec.Members = append(spec.Members, MemberSpec{
+			Name:  c.Name(),
+			Label: label,
+			Value: &value,
+		})
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// parseDir parses the non-test Go files of dir, skipping the excluded file.
+func parseDir(fset *token.FileSet, dir, exclude string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == exclude {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if len(files) > 0 && file.Name.Name != files[0].Name.Name {
+			return nil, errors.New("multiple packages in " + dir)
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
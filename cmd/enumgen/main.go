@@ -0,0 +1,100 @@
+// Command enumgen generates enum definitions and marshalling methods for
+// integer types, so that Go constants and enum labels can never disagree.
+//
+// It is meant to be used with go:generate, either from existing constants:
+//
+//	//go:generate go run github.com/gmllt/enum/cmd/enumgen -type=Status
+//
+// or from a JSON spec file that also declares the constants:
+//
+//	//go:generate go run github.com/gmllt/enum/cmd/enumgen -spec=status.json
+//
+// For a type Status, the generated file declares a StatusEnum variable, the
+// String, MarshalJSON, UnmarshalJSON, Scan and Value methods, and registers
+// the enum with enum.RegisterEnum.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "enumgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run parses the command line, generates the code and writes the output file.
+func run(args []string, stderr io.Writer) error {
+	fs := flag.NewFlagSet("enumgen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	typeName := fs.String("type", "", "name of the enum type whose constants are read from the package")
+	specPath := fs.String("spec", "", "path to a JSON spec file declaring the type and its members")
+	dir := fs.String("dir", ".", "package directory")
+	output := fs.String("output", "", "output file name (default <type>_enum.go in the package directory)")
+	trimPrefix := fs.String("trimprefix", "", "prefix removed from constant names to build labels (default: the type name)")
+	transform := fs.String("transform", TransformSnake, "label transform applied to constant names: none, lower, snake or kebab")
+	varName := fs.String("var", "", "name of the generated enum variable (default <type>Enum)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		spec *Spec
+		err  error
+	)
+	switch {
+	case *specPath != "" && *typeName != "":
+		return errors.New("-type and -spec are mutually exclusive")
+	case *specPath != "":
+		spec, err = LoadSpecFile(*specPath)
+	case *typeName != "":
+		outName := *output
+		if outName == "" {
+			outName = defaultOutput(*typeName)
+		}
+		spec, err = LoadPackage(*dir, *typeName, LoadOptions{
+			TrimPrefix: *trimPrefix,
+			Transform:  *transform,
+			Exclude:    filepath.Base(outName),
+		})
+	default:
+		fs.Usage()
+		return errors.New("one of -type or -spec is required")
+	}
+	if err != nil {
+		return err
+	}
+
+	if *varName != "" {
+		spec.Var = *varName
+	}
+
+	src, err := Generate(spec)
+	if err != nil {
+		return err
+	}
+
+	outPath := *output
+	if outPath == "" {
+		outPath = defaultOutput(spec.Type)
+	}
+	if !filepath.IsAbs(outPath) {
+		outPath = filepath.Join(*dir, outPath)
+	}
+	return os.WriteFile(outPath, src, 0o600)
+}
+
+// defaultOutput returns the default output file name for a type.
+func defaultOutput(typeName string) string {
+	return strings.ToLower(typeName) + "_enum.go"
+}
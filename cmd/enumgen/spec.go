@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// Label transforms applied to constant names in package mode.
+const (
+	TransformNone  = "none"
+	TransformLower = "lower"
+	TransformSnake = "snake"
+	TransformKebab = "kebab"
+)
+
+// Spec describes an enum type and its members.
+type Spec struct {
+	// Package is the name of the package of the generated file.
+	Package string `json:"package"`
+	// Type is the name of the enum type.
+	Type string `json:"type"`
+	// Underlying is the underlying integer type. When set, the type
+	// declaration and the constants are generated as well.
+	Underlying string `json:"underlying,omitempty"`
+	// Var is the name of the generated enum variable.
+	Var string `json:"var,omitempty"`
+	// Members lists the constants of the type in declaration order.
+	Members []MemberSpec `json:"members"`
+}
+
+// MemberSpec describes a single enum constant.
+type MemberSpec struct {
+	// Name is the Go constant name.
+	Name string `json:"name"`
+	// Label is the string label of the constant.
+	Label string `json:"label"`
+	// Value is the constant value. When nil in a spec file, the position is used.
+	Value *int64 `json:"value,omitempty"`
+}
+
+// LoadSpecFile reads a JSON spec file. The constants are generated, so the
+// underlying type defaults to int when it is not given.
+func LoadSpecFile(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parse spec %s: %w", path, err)
+	}
+	if spec.Underlying == "" {
+		spec.Underlying = "int"
+	}
+	for i := range spec.Members {
+		if spec.Members[i].Value == nil {
+			v := int64(i)
+			spec.Members[i].Value = &v
+		}
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid spec %s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// Validate checks that the spec can be generated and fills in defaults.
+func (s *Spec) Validate() error {
+	if !token.IsIdentifier(s.Package) {
+		return fmt.Errorf("invalid package name %q", s.Package)
+	}
+	if !token.IsIdentifier(s.Type) {
+		return fmt.Errorf("invalid type name %q", s.Type)
+	}
+	if len(s.Members) == 0 {
+		return fmt.Errorf("type %s has no members", s.Type)
+	}
+	if s.Var == "" {
+		s.Var = s.Type + "Enum"
+	}
+
+	names := make(map[string]bool, len(s.Members))
+	labels := make(map[string]bool, len(s.Members))
+	values := make(map[int64]string, len(s.Members))
+	for _, m := range s.Members {
+		if !token.IsIdentifier(m.Name) {
+			return fmt.Errorf("invalid constant name %q", m.Name)
+		}
+		if m.Value == nil {
+			return fmt.Errorf("constant %s has no value", m.Name)
+		}
+		if names[m.Name] {
+			return fmt.Errorf("duplicate constant %s", m.Name)
+		}
+		if labels[m.Label] {
+			return fmt.Errorf("duplicate label %q", m.Label)
+		}
+		if other, ok := values[*m.Value]; ok {
+			return fmt.Errorf("constants %s and %s share value %d", other, m.Name, *m.Value)
+		}
+		names[m.Name] = true
+		labels[m.Label] = true
+		values[*m.Value] = m.Name
+	}
+	return nil
+}
+
+// ApplyTransform converts a constant name, without its prefix, to a label.
+func ApplyTransform(name, transform string) (string, error) {
+	switch transform {
+	case TransformNone:
+		return name, nil
+	case TransformLower:
+		return strings.ToLower(name), nil
+	case TransformSnake:
+		return strings.Join(splitWords(name), "_"), nil
+	case TransformKebab:
+		return strings.Join(splitWords(name), "-"), nil
+	default:
+		return "", errors.New("unknown transform " + transform)
+	}
+}
+
+// splitWords splits a Go identifier into lower-case words at case changes
+// and underscores, keeping acronyms together ("HTTPServer" → "http", "server").
+func splitWords(name string) []string {
+	var (
+		words   []string
+		current []rune
+	)
+	runes := []rune(name)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+			continue
+		case unicode.IsUpper(r) && len(current) > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/gmllt/enum/protoenum"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const enumPackage = protogen.GoImportPath("github.com/gmllt/enum")
+
+// generate writes an enum file for every requested .proto file that declares enums.
+func (p *plugin) generate(gen *protogen.Plugin) error {
+	opts, err := p.protoOptions()
+	if err != nil {
+		return err
+	}
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		enums := collectEnums(file.Enums, file.Messages)
+		if len(enums) == 0 {
+			continue
+		}
+		if err := generateFile(gen, file, enums, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectEnums returns the enums of a file, including the ones nested in messages.
+func collectEnums(enums []*protogen.Enum, messages []*protogen.Message) []*protogen.Enum {
+	all := append([]*protogen.Enum(nil), enums...)
+	for _, message := range messages {
+		all = append(all, collectEnums(message.Enums, message.Messages)...)
+	}
+	return all
+}
+
+// generateFile writes the enum definitions of one .proto file.
+func generateFile(gen *protogen.Plugin, file *protogen.File, enums []*protogen.Enum, opts protoenum.Options) error {
+	g := gen.NewGeneratedFile(file.GeneratedFilenamePrefix+"_enum.pb.go", file.GoImportPath)
+	g.P("// Code generated by protoc-gen-enum; DO NOT EDIT.")
+	g.P("// source: ", file.Desc.Path())
+	g.P()
+	g.P("package ", file.GoPackageName)
+
+	for _, e := range enums {
+		if err := generateEnum(g, e, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateEnum writes the enum variable of e and its registration. The
+// members are selected by protoenum.Members, so the generated enum and one
+// built at run time with protoenum.NewEnum are the same.
+func generateEnum(g *protogen.GeneratedFile, e *protogen.Enum, opts protoenum.Options) error {
+	members, err := protoenum.Members(e.Desc, opts)
+	if err != nil {
+		return err
+	}
+	constants := make(map[protoreflect.Name]protogen.GoIdent, len(e.Values))
+	for _, value := range e.Values {
+		constants[value.Desc.Name()] = value.GoIdent
+	}
+
+	varName := e.GoIdent.GoName + "Enum"
+	typeName := g.QualifiedGoIdent(e.GoIdent)
+	writeMember := func(kind string, m protoenum.Member) {
+		g.P(enumPackage.Ident(kind), "[", typeName, "]{Label: ", strconv.Quote(m.Label),
+			", Value: ", constants[m.Desc.Name()], "},")
+	}
+
+	g.P()
+	g.P("// ", varName, " is the enum definition of ", e.GoIdent.GoName, ".")
+	g.P("var ", varName, " = ", enumPackage.Ident("NewEnumFromMembers"), "(")
+	hasAliases := false
+	for _, m := range members {
+		if m.Alias {
+			hasAliases = true
+			continue
+		}
+		writeMember("Member", m)
+	}
+	if hasAliases {
+		g.P(").WithAliases(")
+		for _, m := range members {
+			if m.Alias {
+				writeMember("Alias", m)
+			}
+		}
+	}
+	g.P(")")
+	g.P()
+	g.P("func init() {")
+	g.P("if err := ", enumPackage.Ident("RegisterEnum"), "(", varName, ", ", enumPackage.Ident("ConflictError"), "); err != nil {")
+	g.P("panic(err)")
+	g.P("}")
+	g.P("}")
+	return nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// ordersFile returns a .proto file with a top-level enum using aliases and
+// a message with a nested enum.
+func ordersFile() *descriptorpb.FileDescriptorProto {
+	value := func(name string, number int32) *descriptorpb.EnumValueDescriptorProto {
+		return &descriptorpb.EnumValueDescriptorProto{Name: proto.String(name), Number: proto.Int32(number)}
+	}
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("orders/v1/orders.proto"),
+		Package: proto.String("orders.v1"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("example.com/orders/v1;ordersv1")},
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name:    proto.String("OrderStatus"),
+			Options: &descriptorpb.EnumOptions{AllowAlias: proto.Bool(true)},
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				value("ORDER_STATUS_UNSPECIFIED", 0),
+				value("ORDER_STATUS_PENDING", 1),
+				value("ORDER_STATUS_SHIPPED", 2),
+				value("ORDER_STATUS_SENT", 2),
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("Order"),
+			EnumType: []*descriptorpb.EnumDescriptorProto{{
+				Name: proto.String("Priority"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					value("PRIORITY_UNSPECIFIED", 0),
+					value("PRIORITY_HIGH", 1),
+				},
+			}},
+		}},
+	}
+}
+
+// runPlugin runs the plugin on files with the given parameter and returns the response.
+func runPlugin(t *testing.T, parameter string, files ...*descriptorpb.FileDescriptorProto) *pluginpb.CodeGeneratorResponse {
+	t.Helper()
+	req := &pluginpb.CodeGeneratorRequest{
+		Parameter: proto.String(parameter),
+		ProtoFile: files,
+	}
+	for _, f := range files {
+		req.FileToGenerate = append(req.FileToGenerate, f.GetName())
+	}
+
+	p := newPlugin()
+	gen, err := p.options().New(req)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if err := p.generate(gen); err != nil {
+		gen.Error(err)
+	}
+	return gen.Response()
+}
+
+// TestGenerate tests the generated enum definitions
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter string
+		contains  []string
+		excludes  []string
+	}{
+		{
+			name:      "names",
+			parameter: "",
+			contains: []string{
+				"// Code generated by protoc-gen-enum; DO NOT EDIT.",
+				"// source: orders/v1/orders.proto",
+				"package ordersv1",
+				`"github.com/gmllt/enum"`,
+				"var OrderStatusEnum = enum.NewEnumFromMembers(",
+				`enum.Member[OrderStatus]{Label: "ORDER_STATUS_UNSPECIFIED", Value: OrderStatus_ORDER_STATUS_UNSPECIFIED},`,
+				`enum.Member[OrderStatus]{Label: "ORDER_STATUS_SHIPPED", Value: OrderStatus_ORDER_STATUS_SHIPPED},`,
+				").WithAliases(",
+				`enum.Alias[OrderStatus]{Label: "ORDER_STATUS_SENT", Value: OrderStatus_ORDER_STATUS_SENT},`,
+				"if err := enum.RegisterEnum(OrderStatusEnum, enum.ConflictError); err != nil {",
+				"var Order_PriorityEnum = enum.NewEnumFromMembers(",
+				`enum.Member[Order_Priority]{Label: "PRIORITY_HIGH", Value: Order_PRIORITY_HIGH},`,
+			},
+		},
+		{
+			name:      "short labels without unspecified",
+			parameter: "labels=short,omit_unspecified=true",
+			contains: []string{
+				`enum.Member[OrderStatus]{Label: "pending", Value: OrderStatus_ORDER_STATUS_PENDING},`,
+				`enum.Alias[OrderStatus]{Label: "sent", Value: OrderStatus_ORDER_STATUS_SENT},`,
+				`enum.Member[Order_Priority]{Label: "high", Value: Order_PRIORITY_HIGH},`,
+			},
+			excludes: []string{"UNSPECIFIED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := runPlugin(t, tt.parameter, ordersFile())
+			if resp.Error != nil {
+				t.Fatalf("plugin failed: %s", resp.GetError())
+			}
+			if len(resp.File) != 1 || resp.File[0].GetName() != "example.com/orders/v1/orders_enum.pb.go" {
+				t.Fatalf("expected example.com/orders/v1/orders_enum.pb.go, got %v", resp.File)
+			}
+
+			src := resp.File[0].GetContent()
+			if _, err := parser.ParseFile(token.NewFileSet(), "orders_enum.pb.go", src, 0); err != nil {
+				t.Fatalf("generated code does not parse: %v\n%s", err, src)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(src, want) {
+					t.Errorf("expected generated code to contain %q\n%s", want, src)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(src, unwanted) {
+					t.Errorf("expected generated code not to contain %q\n%s", unwanted, src)
+				}
+			}
+		})
+	}
+}
+
+// TestGenerateErrors tests invalid parameters and enums
+func TestGenerateErrors(t *testing.T) {
+	if resp := runPlugin(t, "labels=loud", ordersFile()); !strings.Contains(resp.GetError(), "unknown label style") {
+		t.Errorf("expected an unknown label style error, got %q", resp.GetError())
+	}
+
+	// Short labels can collide with names that do not use the prefix, which
+	// proto3 rejects but proto2 allows
+	file := ordersFile()
+	file.Syntax = proto.String("proto2")
+	file.EnumType[0].Value[3].Name = proto.String("PENDING")
+	file.EnumType[0].Value[3].Number = proto.Int32(3)
+	file.EnumType[0].Options = nil
+	if resp := runPlugin(t, "labels=short", file); !strings.Contains(resp.GetError(), `duplicate label "pending"`) {
+		t.Errorf("expected a duplicate label error, got %q", resp.GetError())
+	}
+
+	// Files without enums produce no output
+	file.EnumType, file.MessageType = nil, nil
+	if resp := runPlugin(t, "", file); resp.Error != nil || len(resp.File) != 0 {
+		t.Errorf("expected no file, got %v (err %q)", resp.File, resp.GetError())
+	}
+}
@@ -0,0 +1,68 @@
+// Command protoc-gen-enum is a protoc plugin that generates enum definitions
+// for the enums of .proto files, next to the code of protoc-gen-go, so that
+// protobuf values and enum labels can never disagree.
+//
+// It is used like any other plugin:
+//
+//	protoc --go_out=. --enum_out=. --enum_opt=labels=short orders.proto
+//
+// For an enum OrderStatus, the generated <file>_enum.pb.go file declares an
+// OrderStatusEnum variable built with enum.NewEnumFromMembers from the
+// constants of protoc-gen-go, and registers it with enum.RegisterEnum.
+// Values declared with allow_alias become enum aliases. Members are selected
+// like protoenum.NewEnum does at run time.
+//
+// Parameters:
+//
+//	labels=name|short   labels are value names as declared (default), or
+//	                    without the enum name prefix and lower-cased
+//	omit_unspecified    leave out the zero value named *_UNSPECIFIED, so that
+//	                    unset fields are rejected like any non-member
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/gmllt/enum/protoenum"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func main() {
+	p := newPlugin()
+	p.options().Run(p.generate)
+}
+
+// plugin holds the parameters of a protoc run.
+type plugin struct {
+	flags           flag.FlagSet
+	labels          string
+	omitUnspecified bool
+}
+
+// newPlugin returns a plugin with its parameters declared.
+func newPlugin() *plugin {
+	p := &plugin{}
+	p.flags.StringVar(&p.labels, "labels", "name", "label style: name or short")
+	p.flags.BoolVar(&p.omitUnspecified, "omit_unspecified", false, "leave out the zero value named *_UNSPECIFIED")
+	return p
+}
+
+// options returns the protogen options that parse the plugin parameters.
+func (p *plugin) options() protogen.Options {
+	return protogen.Options{ParamFunc: p.flags.Set}
+}
+
+// protoOptions converts the plugin parameters to protoenum.Options.
+func (p *plugin) protoOptions() (protoenum.Options, error) {
+	opts := protoenum.Options{OmitUnspecified: p.omitUnspecified}
+	switch p.labels {
+	case "name":
+		opts.Labels = protoenum.LabelName
+	case "short":
+		opts.Labels = protoenum.LabelShort
+	default:
+		return opts, fmt.Errorf("unknown label style %q, expected name or short", p.labels)
+	}
+	return opts, nil
+}
@@ -2,47 +2,142 @@ package enum
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/gmllt/enum/internal"
+	"gopkg.in/yaml.v3"
 )
 
 // Value is a type constraint for integer values used in the Enum type.
+// Any signed or unsigned integer kind is accepted.
 type Value interface {
-	~int
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Member pairs a label with an explicit enum value.
+type Member[T Value] struct {
+	Label string
+	Value T
+}
+
+// Alias is an alternative spelling of an enum member, such as a former label.
+// Deprecated aliases are reported to the deprecation hook when parsed.
+type Alias[T Value] struct {
+	Label      string
+	Value      T
+	Deprecated bool
 }
 
 // Enum is a generic enumeration type that maps integer values to string labels.
 type Enum[T Value] struct {
-	labels   []string
-	labelMap map[string]T
-	allVals  []T
+	labels  []string
+	allVals []T
+	table   *internal.Table[T]
+	repr    Representation
+	binary  BinaryEncoding
+	// fingerprint identifies the members in BinaryVarintFingerprint data.
+	fingerprint byte
+	// preserveUnknown makes wrappers keep unknown input instead of failing.
+	preserveUnknown bool
+	yamlStyle       yaml.Style
+	yamlComment     bool
 }
 
 // NewEnum creates a new Enum instance with the provided labels.
+// Each label is assigned its position as value (0, 1, 2, ...).
+// It panics with an *ErrTooManyLabels if T cannot represent every position,
+// such as 200 labels for an int8 enum.
 func NewEnum[T Value](labels ...string) *Enum[T] {
-	cacheBuilder := internal.NewCacheBuilder[T](labels)
+	if err := internal.ValidateCapacity[T](len(labels)); err != nil {
+		panic(err)
+	}
+	return newEnum(internal.NewCacheBuilder[T](labels), labels)
+}
+
+// NewEnumFromMembers creates a new Enum instance with explicit values.
+// Values do not need to be contiguous, ordered or start at zero, which makes
+// it possible to model wire protocols or database codes. Members keep their
+// declaration order in All and Labels. Values should be unique: when a value
+// is repeated, String returns the label of its first member.
+func NewEnumFromMembers[T Value](members ...Member[T]) *Enum[T] {
+	labels := make([]string, len(members))
+	values := make([]T, len(members))
+	for i, m := range members {
+		labels[i] = m.Label
+		values[i] = m.Value
+	}
+	return newEnum(internal.NewCacheBuilderWithValues(labels, values), labels)
+}
+
+// newEnum builds an Enum from a cache builder and its labels.
+func newEnum[T Value](cacheBuilder *internal.CacheBuilder[T], labels []string) *Enum[T] {
+	allVals := cacheBuilder.BuildAllValues()
+
+	table := internal.NewTable(labels, allVals)
 
 	return &Enum[T]{
-		labels:   labels,
-		labelMap: cacheBuilder.BuildLookupMap(),
-		allVals:  cacheBuilder.BuildAllValues(),
+		labels:      labels,
+		allVals:     allVals,
+		table:       table,
+		fingerprint: internal.Fingerprint(table),
 	}
 }
 
 // String returns the string representation of the enumeration value.
 func (e *Enum[T]) String(v T) string {
-	return internal.SafeGetLabel(e.labels, v, fmt.Sprintf("Invalid(%d)", v))
+	if label, ok := e.table.Label(v); ok {
+		return label
+	}
+	return fmt.Sprintf("Invalid(%d)", v)
 }
 
 // FromString converts a string to the corresponding enumeration value.
+// The string is matched according to the parse mode of the enum.
 func (e *Enum[T]) FromString(s string) (T, error) {
-	if val, ok := e.labelMap[s]; ok {
+	if val, ok := e.lookup(s); ok {
 		return val, nil
 	}
 	var zero T
 	return zero, fmt.Errorf("invalid value: %s", s)
 }
 
+// FromBytes is like FromString for a label held in a byte slice, such as a
+// field of a network message. With ParseExact, it does not allocate on success.
+func (e *Enum[T]) FromBytes(b []byte) (T, error) {
+	if val, ok := e.table.LookupBytes(b); ok {
+		return val, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("invalid value: %s", b)
+}
+
+// AppendText appends the label of v to dst.
+func (e *Enum[T]) AppendText(dst []byte, v T) ([]byte, error) {
+	return internal.AppendText(e.table, dst, v)
+}
+
+// AppendJSON appends the JSON form of v to dst, following the representation of the enum.
+func (e *Enum[T]) AppendJSON(dst []byte, v T) ([]byte, error) {
+	return internal.AppendJSONAs(e.table, dst, v, e.repr)
+}
+
+// AppendBinary appends the binary form of v to dst, following the binary encoding of the enum.
+func (e *Enum[T]) AppendBinary(dst []byte, v T) ([]byte, error) {
+	return internal.AppendBinaryAs(e.table, dst, v, e.binary, e.fingerprint)
+}
+
+// ReadBinary reads one binary-encoded value from r, in any binary encoding,
+// without reading past it. It returns io.EOF if r is empty.
+func (e *Enum[T]) ReadBinary(r io.Reader) (T, error) {
+	frame, err := internal.ReadBinaryFrame(r)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return internal.FromBinaryAs(e.table, frame, e.fingerprint)
+}
+
 // All returns all values of the enum.
 func (e *Enum[T]) All() []T {
 	res := make([]T, len(e.allVals))
@@ -64,3 +159,118 @@ func (e *Enum[T]) Labels() []string {
 func (e *Enum[T]) LabelsReadOnly() []string {
 	return e.labels
 }
+
+// Contains reports whether v is a member of the enum.
+func (e *Enum[T]) Contains(v T) bool {
+	return e.table.Contains(v)
+}
+
+// WithParseMode returns a copy of the enum that matches input labels under mode
+// in FromString and every unmarshaller. When several labels are equivalent
+// under mode, the first one wins.
+func (e *Enum[T]) WithParseMode(mode ParseMode) *Enum[T] {
+	cp := *e
+	cp.table = e.table.WithMode(mode)
+	return &cp
+}
+
+// ParseMode returns the parse mode of the enum.
+func (e *Enum[T]) ParseMode() ParseMode {
+	return e.table.Mode()
+}
+
+// WithAliases returns a copy of the enum that also accepts the given aliases
+// in FromString and every unmarshaller. Aliases resolve to the canonical
+// value, so String and every marshaller keep emitting the canonical label.
+// It panics if an alias refers to a value that is not a member of the enum.
+func (e *Enum[T]) WithAliases(aliases ...Alias[T]) *Enum[T] {
+	converted := make([]internal.Alias, len(aliases))
+	for i, alias := range aliases {
+		index, ok := e.table.Index(alias.Value)
+		if !ok {
+			panic(fmt.Errorf("enum: alias %q refers to unknown value %d", alias.Label, alias.Value))
+		}
+		converted[i] = internal.Alias{Label: alias.Label, Index: index, Deprecated: alias.Deprecated}
+	}
+
+	cp := *e
+	cp.table = e.table.WithAliases(converted...)
+	return &cp
+}
+
+// WithDeprecationHook returns a copy of the enum that calls hook whenever
+// a deprecated alias is parsed, with the received alias and the canonical label.
+// The hook is called synchronously and must be safe for concurrent use.
+func (e *Enum[T]) WithDeprecationHook(hook func(alias, label string)) *Enum[T] {
+	cp := *e
+	cp.table = e.table.WithAliasHook(hook)
+	return &cp
+}
+
+// WithRepresentation returns a copy of the enum that uses r in the JSON and
+// SQL marshalling of its wrappers.
+func (e *Enum[T]) WithRepresentation(r Representation) *Enum[T] {
+	cp := *e
+	cp.repr = r
+	return &cp
+}
+
+// Representation returns the JSON and SQL representation of the enum.
+func (e *Enum[T]) Representation() Representation {
+	return e.repr
+}
+
+// WithBinaryEncoding returns a copy of the enum whose wrappers write binary
+// data with enc. Wrappers read every encoding regardless.
+func (e *Enum[T]) WithBinaryEncoding(enc BinaryEncoding) *Enum[T] {
+	cp := *e
+	cp.binary = enc
+	return &cp
+}
+
+// BinaryEncoding returns the binary encoding of the enum.
+func (e *Enum[T]) BinaryEncoding() BinaryEncoding {
+	return e.binary
+}
+
+// WithPreserveUnknown returns a copy of the enum whose wrappers keep unknown
+// input instead of returning ErrInvalidEnumValue, for forward compatibility
+// with newer producers. The wrapper then reports IsUnknown and marshals the
+// original input unchanged, until a known value is decoded or Set.
+func (e *Enum[T]) WithPreserveUnknown(preserve bool) *Enum[T] {
+	cp := *e
+	cp.preserveUnknown = preserve
+	return &cp
+}
+
+// WithYAMLStyle returns a copy of the enum whose wrappers write YAML labels
+// with style, such as yaml.DoubleQuotedStyle.
+func (e *Enum[T]) WithYAMLStyle(style yaml.Style) *Enum[T] {
+	cp := *e
+	cp.yamlStyle = style
+	return &cp
+}
+
+// WithYAMLComment returns a copy of the enum whose wrappers write a line
+// comment listing the valid labels next to each YAML value, which helps
+// people editing configuration files.
+func (e *Enum[T]) WithYAMLComment(comment bool) *Enum[T] {
+	cp := *e
+	cp.yamlComment = comment
+	return &cp
+}
+
+// WithLenientMarshal returns a copy of the enum whose label marshallers
+// (JSON, YAML, text and binary) write "Invalid" for a value that is not a
+// member, as earlier versions did, instead of returning *ErrValueOutOfRange.
+// Invalid labels cannot be decoded again, so this is meant for migrations only.
+func (e *Enum[T]) WithLenientMarshal(lenient bool) *Enum[T] {
+	cp := *e
+	cp.table = e.table.WithLenientMarshal(lenient)
+	return &cp
+}
+
+// lookup returns the value of a label according to the parse mode and aliases.
+func (e *Enum[T]) lookup(s string) (T, bool) {
+	return e.table.Lookup(s)
+}
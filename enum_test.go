@@ -1,7 +1,10 @@
 package enum
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"testing"
 )
@@ -42,10 +45,6 @@ func TestNewEnum(t *testing.T) {
 				t.Errorf("expected %d labels, got %d", len(tt.labels), len(enum.labels))
 			}
 
-			if len(enum.labelMap) != len(tt.labels) {
-				t.Errorf("expected labelMap size %d, got %d", len(tt.labels), len(enum.labelMap))
-			}
-
 			if len(enum.allVals) != len(tt.labels) {
 				t.Errorf("expected allVals size %d, got %d", len(tt.labels), len(enum.allVals))
 			}
@@ -295,3 +294,219 @@ func TestEnumWithDifferentValues(t *testing.T) {
 		t.Errorf("expected 'first', got %q", str)
 	}
 }
+
+// TestNewEnumFromMembers tests enums with explicit, non-contiguous values
+func TestNewEnumFromMembers(t *testing.T) {
+	type AccountState int
+
+	enum := NewEnumFromMembers(
+		Member[AccountState]{Label: "active", Value: 10},
+		Member[AccountState]{Label: "suspended", Value: 20},
+		Member[AccountState]{Label: "deleted", Value: 99},
+	)
+
+	if got := enum.String(20); got != "suspended" {
+		t.Errorf("expected %q, got %q", "suspended", got)
+	}
+
+	if got := enum.String(1); got != "Invalid(1)" {
+		t.Errorf("expected %q, got %q", "Invalid(1)", got)
+	}
+
+	val, err := enum.FromString("deleted")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if val != 99 {
+		t.Errorf("expected 99, got %d", val)
+	}
+
+	expectedVals := []AccountState{10, 20, 99}
+	if !reflect.DeepEqual(enum.All(), expectedVals) {
+		t.Errorf("expected %v, got %v", expectedVals, enum.All())
+	}
+
+	expectedLabels := []string{"active", "suspended", "deleted"}
+	if !reflect.DeepEqual(enum.Labels(), expectedLabels) {
+		t.Errorf("expected %v, got %v", expectedLabels, enum.Labels())
+	}
+
+	if !enum.Contains(99) || enum.Contains(0) {
+		t.Error("Contains should only report declared values")
+	}
+}
+
+// TestEnumIntegerKinds tests enums backed by sized and unsigned integer types
+func TestEnumIntegerKinds(t *testing.T) {
+	type Priority int8
+	type Code uint16
+
+	priorities := NewEnum[Priority]("low", "medium", "high")
+	if got := priorities.String(2); got != "high" {
+		t.Errorf("expected %q, got %q", "high", got)
+	}
+	if got := priorities.String(-1); got != "Invalid(-1)" {
+		t.Errorf("expected %q, got %q", "Invalid(-1)", got)
+	}
+
+	codes := NewEnumFromMembers(
+		Member[Code]{Label: "ok", Value: 200},
+		Member[Code]{Label: "not_found", Value: 404},
+		Member[Code]{Label: "teapot", Value: 65000},
+	)
+	val, err := codes.FromString("teapot")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if val != 65000 {
+		t.Errorf("expected 65000, got %d", val)
+	}
+
+	bytes := NewEnum[uint8](generateEnumLabels(256)...)
+	if got := bytes.String(255); got != "label_255" {
+		t.Errorf("expected %q, got %q", "label_255", got)
+	}
+}
+
+// TestNewEnumTooManyLabels tests that NewEnum refuses labels the type cannot index
+func TestNewEnumTooManyLabels(t *testing.T) {
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok {
+			t.Fatalf("expected panic with an error, got %v", r)
+		}
+		var tooMany *ErrTooManyLabels
+		if !errors.As(err, &tooMany) {
+			t.Fatalf("expected ErrTooManyLabels, got %T", err)
+		}
+		if tooMany.Count != 129 || tooMany.Max != 128 {
+			t.Errorf("expected count 129 and max 128, got %d and %d", tooMany.Count, tooMany.Max)
+		}
+	}()
+
+	NewEnum[int8](generateEnumLabels(129)...)
+}
+
+func generateEnumLabels(count int) []string {
+	labels := make([]string, count)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("label_%d", i)
+	}
+	return labels
+}
+
+// TestEnumWithAliases tests that aliases resolve to canonical values
+func TestEnumWithAliases(t *testing.T) {
+	var deprecated []string
+	base := NewEnum[int]("pending", "cancelled")
+	enum := base.
+		WithAliases(
+			Alias[int]{Label: "canceled", Value: 1, Deprecated: true},
+			Alias[int]{Label: "waiting", Value: 0},
+		).
+		WithDeprecationHook(func(alias, label string) {
+			deprecated = append(deprecated, alias+"->"+label)
+		})
+
+	val, err := enum.FromString("canceled")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if val != 1 {
+		t.Errorf("expected 1, got %d", val)
+	}
+	if enum.String(val) != "cancelled" {
+		t.Errorf("expected canonical label %q, got %q", "cancelled", enum.String(val))
+	}
+
+	if val, err := enum.FromString("waiting"); err != nil || val != 0 {
+		t.Errorf("expected (0, nil), got (%d, %v)", val, err)
+	}
+
+	if len(deprecated) != 1 || deprecated[0] != "canceled->cancelled" {
+		t.Errorf("expected one deprecation report, got %v", deprecated)
+	}
+
+	if _, err := base.FromString("canceled"); err == nil {
+		t.Error("WithAliases should not modify the original enum")
+	}
+
+	if !reflect.DeepEqual(enum.Labels(), []string{"pending", "cancelled"}) {
+		t.Errorf("aliases should not appear in labels, got %v", enum.Labels())
+	}
+}
+
+// TestEnumWithAliasesUnknownValue tests that aliases must refer to members
+func TestEnumWithAliasesUnknownValue(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for alias of unknown value")
+		}
+	}()
+
+	NewEnum[int]("pending", "cancelled").WithAliases(Alias[int]{Label: "archived", Value: 7})
+}
+
+// TestEnumFromBytes tests lookups of labels held in byte slices
+func TestEnumFromBytes(t *testing.T) {
+	e := NewEnum[int]("pending", "active", "shipped", "cancelled", "lost", "on_hold")
+
+	for i, label := range e.Labels() {
+		v, err := e.FromBytes([]byte(label))
+		if err != nil || v != i {
+			t.Errorf("FromBytes(%q) = (%d, %v), want (%d, nil)", label, v, err, i)
+		}
+	}
+
+	if _, err := e.FromBytes([]byte("unknown")); err == nil {
+		t.Error("expected error for unknown label")
+	}
+
+	relaxed := e.WithParseMode(ParseCaseInsensitive)
+	if v, err := relaxed.FromBytes([]byte("SHIPPED")); err != nil || v != 2 {
+		t.Errorf("expected (2, nil), got (%d, %v)", v, err)
+	}
+}
+
+// TestEnumAppend tests appending values to an existing buffer
+func TestEnumAppend(t *testing.T) {
+	e := NewEnum[int]("low", "high")
+
+	buf := []byte("level=")
+	buf, _ = e.AppendText(buf, 1)
+	buf = append(buf, ' ')
+	buf, _ = e.AppendJSON(buf, 0)
+	if string(buf) != `level=high "low"` {
+		t.Errorf("unexpected buffer %q", buf)
+	}
+
+	if got, err := e.WithRepresentation(RepresentNumber).AppendJSON(nil, 1); err != nil || string(got) != "1" {
+		t.Errorf("expected 1, got %s (err %v)", got, err)
+	}
+	if _, err := e.WithRepresentation(RepresentNumber).AppendJSON(nil, 5); err == nil {
+		t.Error("expected error for non-member value")
+	}
+}
+
+// TestEnumReadBinary tests reading a stream of binary values
+func TestEnumReadBinary(t *testing.T) {
+	e := NewEnum[int]("low", "medium", "high")
+	compact := e.WithBinaryEncoding(BinaryVarint)
+
+	var stream []byte
+	stream, _ = e.AppendBinary(stream, 2)
+	stream, _ = compact.AppendBinary(stream, 0)
+	stream, _ = e.AppendBinary(stream, 1)
+
+	r := bytes.NewReader(stream)
+	for _, want := range []int{2, 0, 1} {
+		v, err := e.ReadBinary(r)
+		if err != nil || v != want {
+			t.Fatalf("expected %d, got %d (err %v)", want, v, err)
+		}
+	}
+	if _, err := e.ReadBinary(r); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
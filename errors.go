@@ -18,9 +18,32 @@ type ErrBinaryDataTooShort = internal.ErrBinaryDataTooShort
 // ErrBinaryDataTruncated is returned when binary data is truncated.
 type ErrBinaryDataTruncated = internal.ErrBinaryDataTruncated
 
+// ErrBinaryFingerprintMismatch is returned when binary data was written by a different version of the enum.
+type ErrBinaryFingerprintMismatch = internal.ErrBinaryFingerprintMismatch
+
 // ErrLabelTooLong is returned when a label exceeds the maximum allowed length for binary encoding.
 type ErrLabelTooLong = internal.ErrLabelTooLong
 
+// ErrTooManyLabels is returned when an enum has more labels than its value type can represent.
+type ErrTooManyLabels = internal.ErrTooManyLabels
+
+// ErrRegistryConflict is returned when a type is registered again with a different definition.
+type ErrRegistryConflict = internal.ErrRegistryConflict
+
+// ErrRegistrySealed is returned when registering a new definition in a sealed registry.
+type ErrRegistrySealed = internal.ErrRegistrySealed
+
+// ErrEnumNotConfigured is returned when a wrapper has no enum and none is registered for its type.
+type ErrEnumNotConfigured = internal.ErrEnumNotConfigured
+
+// ErrRegistryKindMismatch is returned when a wrapper resolves its enum from a
+// registry entry of another kind, such as flags registered for a Wrapper type.
+type ErrRegistryKindMismatch = internal.ErrRegistryKindMismatch
+
+// ErrValueOutOfRange is returned when marshalling a value that is not a member of the enum.
+// Min and Max are the smallest and largest members.
+type ErrValueOutOfRange = internal.ErrValueOutOfRange
+
 // Helper functions for creating error instances (optional, for convenience).
 
 // NewInvalidEnumValueError creates a new ErrInvalidEnumValue.
@@ -29,6 +52,11 @@ func NewInvalidEnumValueError(value string, validValues []string) *ErrInvalidEnu
 	return internal.NewInvalidEnumValueError(value, validValues)
 }
 
+// NewInvalidEnumValueErrorAt creates a new ErrInvalidEnumValue located at a line and column of a YAML document.
+func NewInvalidEnumValueErrorAt(value string, validValues []string, line, column int) *ErrInvalidEnumValue {
+	return internal.NewInvalidEnumValueErrorAt(value, validValues, line, column)
+}
+
 // NewBinaryDataTooShortError creates a new ErrBinaryDataTooShort.
 func NewBinaryDataTooShortError(expected, actual int) *ErrBinaryDataTooShort {
 	return internal.NewBinaryDataTooShortError(expected, actual)
@@ -43,3 +71,38 @@ func NewBinaryDataTruncatedError(expected, actual int) *ErrBinaryDataTruncated {
 func NewLabelTooLongError(length, maxLength int) *ErrLabelTooLong {
 	return internal.NewLabelTooLongError(length, maxLength)
 }
+
+// NewTooManyLabelsError creates a new ErrTooManyLabels.
+func NewTooManyLabelsError(count, maxLabels int) *ErrTooManyLabels {
+	return internal.NewTooManyLabelsError(count, maxLabels)
+}
+
+// NewRegistryConflictError creates a new ErrRegistryConflict.
+func NewRegistryConflictError(typeName string, existing, labels []string) *ErrRegistryConflict {
+	return internal.NewRegistryConflictError(typeName, existing, labels)
+}
+
+// NewRegistrySealedError creates a new ErrRegistrySealed.
+func NewRegistrySealedError(typeName string) *ErrRegistrySealed {
+	return internal.NewRegistrySealedError(typeName)
+}
+
+// NewBinaryFingerprintMismatchError creates a new ErrBinaryFingerprintMismatch.
+func NewBinaryFingerprintMismatchError(expected, actual byte) *ErrBinaryFingerprintMismatch {
+	return internal.NewBinaryFingerprintMismatchError(expected, actual)
+}
+
+// NewEnumNotConfiguredError creates a new ErrEnumNotConfigured.
+func NewEnumNotConfiguredError(typeName string) *ErrEnumNotConfigured {
+	return internal.NewEnumNotConfiguredError(typeName)
+}
+
+// NewRegistryKindMismatchError creates a new ErrRegistryKindMismatch.
+func NewRegistryKindMismatchError(typeName, registered, expected string) *ErrRegistryKindMismatch {
+	return internal.NewRegistryKindMismatchError(typeName, registered, expected)
+}
+
+// NewValueOutOfRangeError creates a new ErrValueOutOfRange.
+func NewValueOutOfRangeError(value, minValue, maxValue string) *ErrValueOutOfRange {
+	return internal.NewValueOutOfRangeError(value, minValue, maxValue)
+}
@@ -0,0 +1,148 @@
+package enum
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gmllt/enum/internal"
+)
+
+// Flags is a generic bit-flag type that maps power-of-two values to string labels.
+// A value of T holds any combination of flags.
+type Flags[T Value] struct {
+	labels  []string
+	allVals []T
+	mask    T
+	table   *internal.Table[T]
+}
+
+// NewFlags creates a new Flags instance with the provided labels.
+// Each label is assigned the next power of two (1, 2, 4, ...).
+// It panics with an *ErrTooManyLabels if T has fewer positive bits than labels,
+// such as 8 labels for an int8 flag set.
+func NewFlags[T Value](labels ...string) *Flags[T] {
+	if err := internal.ValidateFlagCapacity[T](len(labels)); err != nil {
+		panic(err)
+	}
+
+	allVals := internal.FlagBits[T](len(labels))
+	table := internal.NewTable(labels, allVals)
+
+	return &Flags[T]{
+		labels:  labels,
+		allVals: allVals,
+		mask:    internal.FlagMask(table),
+		table:   table,
+	}
+}
+
+// Has reports whether every flag of flag is set in v.
+func (f *Flags[T]) Has(v, flag T) bool {
+	return v&flag == flag
+}
+
+// Set returns v with the flags of flag set.
+func (f *Flags[T]) Set(v, flag T) T {
+	return v | flag
+}
+
+// Clear returns v with the flags of flag cleared.
+func (f *Flags[T]) Clear(v, flag T) T {
+	return v &^ flag
+}
+
+// Toggle returns v with the flags of flag toggled.
+func (f *Flags[T]) Toggle(v, flag T) T {
+	return v ^ flag
+}
+
+// Split returns the individual flags set in v, in declaration order.
+// Bits that do not belong to any flag are ignored.
+func (f *Flags[T]) Split(v T) []T {
+	res := make([]T, 0, len(f.allVals))
+	for _, bit := range f.allVals {
+		if v&bit == bit {
+			res = append(res, bit)
+		}
+	}
+	return res
+}
+
+// Valid reports whether v only contains known flags.
+func (f *Flags[T]) Valid(v T) bool {
+	return v&^f.mask == 0
+}
+
+// Mask returns the union of all flags.
+func (f *Flags[T]) Mask() T {
+	return f.mask
+}
+
+// String returns the labels of the flags set in v, joined by "|".
+// The empty set is the empty string.
+func (f *Flags[T]) String(v T) string {
+	text, err := internal.FlagsToText(f.table, v)
+	if err != nil {
+		return fmt.Sprintf("Invalid(%d)", v)
+	}
+	return string(text)
+}
+
+// FromString converts labels joined by "|" to the corresponding flag set.
+// Each label is matched according to the parse mode of the flags.
+func (f *Flags[T]) FromString(s string) (T, error) {
+	var v T
+	if s == "" {
+		return v, nil
+	}
+	for _, label := range strings.Split(s, internal.FlagSeparator) {
+		bit, ok := f.lookup(label)
+		if !ok {
+			var zero T
+			return zero, fmt.Errorf("invalid value: %s", label)
+		}
+		v |= bit
+	}
+	return v, nil
+}
+
+// All returns every individual flag.
+func (f *Flags[T]) All() []T {
+	res := make([]T, len(f.allVals))
+	copy(res, f.allVals)
+	return res
+}
+
+// Labels returns all labels of the flags.
+// Note: The returned slice is a copy to prevent modification of internal state.
+// For read-only access, consider using LabelsReadOnly() for better performance.
+func (f *Flags[T]) Labels() []string {
+	cp := make([]string, len(f.labels))
+	copy(cp, f.labels)
+	return cp
+}
+
+// LabelsReadOnly returns a read-only view of all labels.
+// WARNING: Do not modify the returned slice as it shares memory with the flags.
+func (f *Flags[T]) LabelsReadOnly() []string {
+	return f.labels
+}
+
+// WithParseMode returns a copy of the flags that matches input labels under mode
+// in FromString and every unmarshaller. When several labels are equivalent
+// under mode, the first one wins.
+func (f *Flags[T]) WithParseMode(mode ParseMode) *Flags[T] {
+	cp := *f
+	cp.table = f.table.WithMode(mode)
+	return &cp
+}
+
+// ParseMode returns the parse mode of the flags.
+func (f *Flags[T]) ParseMode() ParseMode {
+	return f.table.Mode()
+}
+
+// lookup returns the value of a label according to the parse mode.
+func (f *Flags[T]) lookup(s string) (T, bool) {
+	return f.table.Lookup(s)
+}
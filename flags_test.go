@@ -0,0 +1,118 @@
+package enum
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type Permission uint8
+
+const (
+	PermRead Permission = 1 << iota
+	PermWrite
+	PermExec
+)
+
+// TestNewFlags tests the creation of flag definitions
+func TestNewFlags(t *testing.T) {
+	perms := NewFlags[Permission]("read", "write", "exec")
+
+	expected := []Permission{PermRead, PermWrite, PermExec}
+	if !reflect.DeepEqual(perms.All(), expected) {
+		t.Errorf("expected %v, got %v", expected, perms.All())
+	}
+
+	if perms.Mask() != PermRead|PermWrite|PermExec {
+		t.Errorf("expected mask 7, got %d", perms.Mask())
+	}
+
+	if !reflect.DeepEqual(perms.Labels(), []string{"read", "write", "exec"}) {
+		t.Errorf("unexpected labels %v", perms.Labels())
+	}
+}
+
+// TestFlagsOperations tests set operations on flag values
+func TestFlagsOperations(t *testing.T) {
+	perms := NewFlags[Permission]("read", "write", "exec")
+
+	v := perms.Set(0, PermRead)
+	v = perms.Set(v, PermExec)
+	if v != PermRead|PermExec {
+		t.Errorf("expected 5, got %d", v)
+	}
+
+	if !perms.Has(v, PermRead) || perms.Has(v, PermWrite) {
+		t.Error("Has returned an unexpected result")
+	}
+	if perms.Has(v, PermRead|PermWrite) {
+		t.Error("Has should require every flag of a combined value")
+	}
+
+	v = perms.Clear(v, PermRead)
+	if v != PermExec {
+		t.Errorf("expected 4, got %d", v)
+	}
+
+	v = perms.Toggle(v, PermWrite|PermExec)
+	if v != PermWrite {
+		t.Errorf("expected 2, got %d", v)
+	}
+
+	split := perms.Split(PermRead | PermExec)
+	if !reflect.DeepEqual(split, []Permission{PermRead, PermExec}) {
+		t.Errorf("expected [1 4], got %v", split)
+	}
+
+	if !perms.Valid(PermWrite|PermExec) || perms.Valid(8) {
+		t.Error("Valid returned an unexpected result")
+	}
+}
+
+// TestFlagsString tests string conversion of flag values
+func TestFlagsString(t *testing.T) {
+	perms := NewFlags[Permission]("read", "write", "exec")
+
+	tests := []struct {
+		name     string
+		value    Permission
+		expected string
+	}{
+		{name: "empty set", value: 0, expected: ""},
+		{name: "single flag", value: PermWrite, expected: "write"},
+		{name: "combined flags", value: PermRead | PermWrite, expected: "read|write"},
+		{name: "unknown bits", value: 16, expected: "Invalid(16)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := perms.String(tt.value); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+
+	v, err := perms.FromString("exec|read")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if v != PermRead|PermExec {
+		t.Errorf("expected 5, got %d", v)
+	}
+
+	if _, err := perms.FromString("read|admin"); err == nil {
+		t.Error("expected error for unknown flag")
+	}
+}
+
+// TestNewFlagsTooManyLabels tests that NewFlags refuses more flags than T has bits
+func TestNewFlagsTooManyLabels(t *testing.T) {
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, &ErrTooManyLabels{}) {
+			t.Fatalf("expected panic with ErrTooManyLabels, got %v", err)
+		}
+	}()
+
+	NewFlags[int8]("a", "b", "c", "d", "e", "f", "g", "h")
+}
@@ -0,0 +1,315 @@
+package enum
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"slices"
+
+	"github.com/gmllt/enum/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// FlagsWrapper wraps a Flags definition and a flag set, and provides serialization.
+// Flag sets are marshalled as "read|write" in text and SQL, as a JSON array
+// of labels, as a YAML sequence of labels, and as a list of labels in binary.
+type FlagsWrapper[T Value] struct {
+	Enum    *Flags[T]
+	Current T
+	labels  []string
+	// options holds the With* options of a wrapper without an enum, applied
+	// once ensureEnum resolves it.
+	options []func(*Flags[T]) *Flags[T]
+}
+
+// Ensure FlagsWrapper implements the necessary interfaces.
+var (
+	_ json.Marshaler             = (*FlagsWrapper[int])(nil)
+	_ json.Unmarshaler           = (*FlagsWrapper[int])(nil)
+	_ yaml.Marshaler             = (*FlagsWrapper[int])(nil)
+	_ yaml.Unmarshaler           = (*FlagsWrapper[int])(nil)
+	_ encoding.TextMarshaler     = (*FlagsWrapper[int])(nil)
+	_ encoding.TextUnmarshaler   = (*FlagsWrapper[int])(nil)
+	_ encoding.BinaryMarshaler   = (*FlagsWrapper[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*FlagsWrapper[int])(nil)
+	_ xml.Marshaler              = (*FlagsWrapper[int])(nil)
+	_ xml.Unmarshaler            = (*FlagsWrapper[int])(nil)
+	_ xml.MarshalerAttr          = (*FlagsWrapper[int])(nil)
+	_ xml.UnmarshalerAttr        = (*FlagsWrapper[int])(nil)
+	_ driver.Valuer              = (*FlagsWrapper[int])(nil)
+	_ sql.Scanner                = (*FlagsWrapper[int])(nil)
+)
+
+// NewFlagsWrapper creates a new FlagsWrapper with the given flag labels and
+// registers its definition for T. It panics like NewWrapper if the default
+// registry is sealed.
+func NewFlagsWrapper[T Value](labels ...string) FlagsWrapper[T] {
+	f := NewFlags[T](labels...)
+	if err := defaultRegistry.Register(f, ConflictOverride); err != nil {
+		panic(err)
+	}
+	return FlagsWrapper[T]{
+		Enum:   f,
+		labels: labels,
+	}
+}
+
+// String returns the labels of the wrapped flag set, joined by "|".
+func (w FlagsWrapper[T]) String() string {
+	if err := w.ensureEnum(); err != nil {
+		return fmt.Sprintf("Invalid(%d)", w.Current)
+	}
+	return w.Enum.String(w.Current)
+}
+
+// All returns every individual flag of the wrapped definition, or nil if it
+// is not configured.
+func (w FlagsWrapper[T]) All() []T {
+	if err := w.ensureEnum(); err != nil {
+		return nil
+	}
+	return w.Enum.All()
+}
+
+// Labels returns all labels of the wrapped definition, or nil if it is not configured.
+func (w FlagsWrapper[T]) Labels() []string {
+	if err := w.ensureEnum(); err != nil {
+		return nil
+	}
+	return w.Enum.Labels()
+}
+
+// Get returns the current flag set.
+func (w FlagsWrapper[T]) Get() T {
+	return w.Current
+}
+
+// Has reports whether every flag of flag is set.
+func (w FlagsWrapper[T]) Has(flag T) bool {
+	return w.Current&flag == flag
+}
+
+// Set sets the flags of flag.
+func (w *FlagsWrapper[T]) Set(flag T) {
+	w.Current |= flag
+}
+
+// Clear clears the flags of flag.
+func (w *FlagsWrapper[T]) Clear(flag T) {
+	w.Current &^= flag
+}
+
+// Toggle toggles the flags of flag.
+func (w *FlagsWrapper[T]) Toggle(flag T) {
+	w.Current ^= flag
+}
+
+// Split returns the individual flags of the current flag set, or nil if the
+// wrapper is not configured.
+func (w FlagsWrapper[T]) Split() []T {
+	if err := w.ensureEnum(); err != nil {
+		return nil
+	}
+	return w.Enum.Split(w.Current)
+}
+
+// WithParseMode returns a copy of the wrapper whose enum matches input labels under mode.
+// On a zero-value wrapper, mode applies to the enum registered for T.
+func (w FlagsWrapper[T]) WithParseMode(mode ParseMode) FlagsWrapper[T] {
+	return w.with(func(e *Flags[T]) *Flags[T] { return e.WithParseMode(mode) })
+}
+
+// with applies option to the enum of the wrapper. A zero-value wrapper keeps
+// it until ensureEnum resolves its enum, so that no option is lost when T is
+// not registered yet.
+func (w FlagsWrapper[T]) with(option func(*Flags[T]) *Flags[T]) FlagsWrapper[T] {
+	if w.Enum != nil {
+		w.Enum = option(w.Enum)
+		return w
+	}
+	w.options = append(slices.Clip(w.options), option)
+	return w
+}
+
+// ensureEnum initializes the Enum if it is nil, from the wrapper labels or
+// else from the default registry. It returns an *ErrEnumNotConfigured if
+// neither is available.
+func (w *FlagsWrapper[T]) ensureEnum() error {
+	if w.Enum != nil {
+		return nil
+	}
+	if w.labels != nil {
+		w.Enum = NewFlags[T](w.labels...)
+	} else if r, ok := lookupRegistration[T](defaultRegistry); ok {
+		f, err := registeredFlags[T](r)
+		if err != nil {
+			return err
+		}
+		w.Enum = f
+		w.labels = w.Enum.labels
+	} else {
+		return NewEnumNotConfiguredError(typeKey[T]().String())
+	}
+	for _, option := range w.options {
+		w.Enum = option(w.Enum)
+	}
+	return nil
+}
+
+// registeredFlags returns the flags of a registration, built from its labels
+// for a Register call. It returns an *ErrRegistryKindMismatch for another kind
+// of definition, and an *ErrTooManyLabels if T cannot hold every flag.
+func registeredFlags[T Value](r registration) (*Flags[T], error) {
+	switch r.entry.Kind {
+	case KindFlags:
+		return r.enum.(*Flags[T]), nil
+	case KindLabels:
+		if err := internal.ValidateFlagCapacity[T](len(r.entry.Labels)); err != nil {
+			return nil, err
+		}
+		return NewFlags[T](r.entry.Labels...), nil
+	default:
+		return nil, NewRegistryKindMismatchError(typeKey[T]().String(), r.entry.Kind.String(), KindFlags.String())
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (w FlagsWrapper[T]) MarshalJSON() ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.FlagsToJSON[T](w.Enum.table, w.Current)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (w *FlagsWrapper[T]) UnmarshalJSON(data []byte) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FlagsFromJSON[T](w.Enum.table, data)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (w FlagsWrapper[T]) MarshalYAML() (any, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.FlagsToYAML[T](w.Enum.table, w.Current)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. An unknown flag returns an
+// *ErrInvalidEnumValue holding its line and column.
+func (w *FlagsWrapper[T]) UnmarshalYAML(node *yaml.Node) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FlagsFromYAMLNode[T](w.Enum.table, node)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (w FlagsWrapper[T]) MarshalText() ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.FlagsToText[T](w.Enum.table, w.Current)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (w *FlagsWrapper[T]) UnmarshalText(text []byte) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FlagsFromText[T](w.Enum.table, text)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (w FlagsWrapper[T]) MarshalBinary() ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.FlagsToBinary[T](w.Enum.table, w.Current)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (w *FlagsWrapper[T]) UnmarshalBinary(data []byte) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FlagsFromBinary[T](w.Enum.table, data)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler. The element holds the text form of the value.
+func (w FlagsWrapper[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text, err := w.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (w *FlagsWrapper[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+	return w.UnmarshalText([]byte(text))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (w FlagsWrapper[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, err := w.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (w *FlagsWrapper[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return w.UnmarshalText([]byte(attr.Value))
+}
+
+// Value implements driver.Valuer for SQL integration.
+func (w FlagsWrapper[T]) Value() (driver.Value, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.FlagsToSQLValue[T](w.Enum.table, w.Current)
+}
+
+// Scan implements sql.Scanner for SQL integration.
+func (w *FlagsWrapper[T]) Scan(src any) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FlagsFromSQLValue[T](w.Enum.table, src)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
@@ -0,0 +1,171 @@
+package enum
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Custom types for flags wrapper testing to avoid registry conflicts
+type FlagsWrapperTestType uint16
+
+// TestFlagsWrapperOperations tests flag operations on the wrapped value
+func TestFlagsWrapperOperations(t *testing.T) {
+	perms := NewFlagsWrapper[Permission]("read", "write", "exec")
+
+	perms.Set(PermRead | PermWrite)
+	if !perms.Has(PermWrite) {
+		t.Error("expected write flag to be set")
+	}
+
+	perms.Clear(PermWrite)
+	perms.Toggle(PermExec)
+	if perms.Get() != PermRead|PermExec {
+		t.Errorf("expected 5, got %d", perms.Get())
+	}
+
+	if perms.String() != "read|exec" {
+		t.Errorf("expected %q, got %q", "read|exec", perms.String())
+	}
+
+	if len(perms.Split()) != 2 {
+		t.Errorf("expected 2 flags, got %v", perms.Split())
+	}
+}
+
+// TestFlagsWrapperMarshalling tests all marshalling formats of FlagsWrapper
+func TestFlagsWrapperMarshalling(t *testing.T) {
+	perms := NewFlagsWrapper[Permission]("read", "write", "exec")
+	perms.Set(PermRead | PermWrite)
+
+	jsonData, err := json.Marshal(perms)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	if string(jsonData) != `["read","write"]` {
+		t.Errorf("expected %s, got %s", `["read","write"]`, jsonData)
+	}
+
+	text, err := perms.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText failed: %v", err)
+	}
+	if string(text) != "read|write" {
+		t.Errorf("expected %q, got %q", "read|write", text)
+	}
+
+	sqlValue, err := perms.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	if sqlValue != "read|write" {
+		t.Errorf("expected %q, got %v", "read|write", sqlValue)
+	}
+
+	binaryData, err := perms.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	decoded := NewFlagsWrapper[Permission]("read", "write", "exec")
+	if err := decoded.UnmarshalBinary(binaryData); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if decoded.Get() != PermRead|PermWrite {
+		t.Errorf("expected 3, got %d", decoded.Get())
+	}
+
+	if err := json.Unmarshal([]byte(`["exec"]`), &decoded); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if decoded.Get() != PermExec {
+		t.Errorf("expected 4, got %d", decoded.Get())
+	}
+
+	if err := decoded.UnmarshalText([]byte("write|exec")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if decoded.Get() != PermWrite|PermExec {
+		t.Errorf("expected 6, got %d", decoded.Get())
+	}
+
+	if err := decoded.Scan(nil); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if decoded.Get() != 0 {
+		t.Errorf("expected empty set, got %d", decoded.Get())
+	}
+}
+
+// TestFlagsWrapperInvalidValues tests that unknown flags return ErrInvalidEnumValue
+func TestFlagsWrapperInvalidValues(t *testing.T) {
+	perms := NewFlagsWrapper[Permission]("read", "write", "exec")
+
+	err := perms.UnmarshalText([]byte("read|admin"))
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) {
+		t.Fatalf("expected ErrInvalidEnumValue, got %v", err)
+	}
+	if invalidErr.Value != "admin" {
+		t.Errorf("expected invalid value %q, got %q", "admin", invalidErr.Value)
+	}
+
+	perms.Current = 32
+	if _, err := perms.MarshalJSON(); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue for unknown bits, got %v", err)
+	}
+}
+
+// TestFlagsWrapperEnsureEnum tests lazy initialization from the registry
+func TestFlagsWrapperEnsureEnum(t *testing.T) {
+	_ = NewFlagsWrapper[FlagsWrapperTestType]("email", "sms", "push")
+
+	var channels FlagsWrapper[FlagsWrapperTestType]
+	if err := json.Unmarshal([]byte(`["sms","push"]`), &channels); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if channels.Get() != 6 {
+		t.Errorf("expected 6, got %d", channels.Get())
+	}
+}
+
+// TestFlagsWrapperYAMLDocument tests flag sequences in yaml.v3 documents
+func TestFlagsWrapperYAMLDocument(t *testing.T) {
+	channels := NewFlagsWrapper[FlagsWrapperTestType]("email", "sms", "push")
+	if err := yaml.Unmarshal([]byte("- email\n- push\n"), &channels); err != nil || channels.Get() != 5 {
+		t.Fatalf("expected 5, got %d (err %v)", channels.Get(), err)
+	}
+
+	err := yaml.Unmarshal([]byte("- email\n- fax\n"), &channels)
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) || invalidErr.Line != 2 || invalidErr.Column != 3 {
+		t.Errorf("expected ErrInvalidEnumValue at 2:3, got %v", err)
+	}
+
+	if err := yaml.Unmarshal([]byte("email"), &channels); err == nil {
+		t.Error("expected error for a scalar instead of a sequence")
+	}
+}
+
+// TestFlagsWrapperXML tests flag sets as XML attributes
+func TestFlagsWrapperXML(t *testing.T) {
+	type user struct {
+		Channels FlagsWrapper[FlagsWrapperTestType] `xml:"channels,attr"`
+	}
+	u := user{Channels: NewFlagsWrapper[FlagsWrapperTestType]("email", "sms", "push")}
+	u.Channels.Set(1)
+	u.Channels.Set(4)
+
+	data, err := xml.Marshal(u)
+	if err != nil || string(data) != `<user channels="email|push"></user>` {
+		t.Fatalf("unexpected XML %s (err %v)", data, err)
+	}
+	decoded := user{Channels: u.Channels}
+	decoded.Channels.Current = 0
+	if err := xml.Unmarshal(data, &decoded); err != nil || decoded.Channels.Get() != 5 {
+		t.Errorf("expected 5, got %d (err %v)", decoded.Channels.Get(), err)
+	}
+}
@@ -1,3 +1,8 @@
 module github.com/gmllt/enum
 
 go 1.24.5
+
+require (
+	google.golang.org/protobuf v1.36.11
+	gopkg.in/yaml.v3 v3.0.1
+)
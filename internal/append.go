@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// AppendText appends the text form of an enum value to dst.
+func AppendText[T comparable](t *Table[T], dst []byte, v T) ([]byte, error) {
+	label, err := t.MarshalLabel(v)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, label...), nil
+}
+
+// AppendJSON appends the JSON form of an enum value to dst.
+func AppendJSON[T comparable](t *Table[T], dst []byte, v T) ([]byte, error) {
+	label, err := t.MarshalLabel(v)
+	if err != nil {
+		return dst, err
+	}
+	return AppendJSONString(dst, label), nil
+}
+
+// AppendJSONAs appends the JSON form of an enum value to dst using the given representation.
+func AppendJSONAs[T Integer](t *Table[T], dst []byte, v T, r Representation) ([]byte, error) {
+	if r != RepresentNumber {
+		return AppendJSON(t, dst, v)
+	}
+	if !t.Contains(v) {
+		return dst, t.RangeError(v)
+	}
+	return appendInteger(dst, v), nil
+}
+
+// AppendJSONString appends s to dst as a JSON string, escaped like json.Marshal.
+// Strings that need no escaping are appended without allocating.
+func AppendJSONString(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 0x20 || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' || c >= utf8.RuneSelf {
+			b, _ := json.Marshal(s)
+			return append(dst, b...)
+		}
+	}
+	dst = append(dst, '"')
+	dst = append(dst, s...)
+	return append(dst, '"')
+}
+
+// AppendBinary appends the length-prefixed label of an enum value to dst.
+func AppendBinary[T comparable](t *Table[T], dst []byte, v T) ([]byte, error) {
+	label, err := t.MarshalLabel(v)
+	if err != nil {
+		return dst, err
+	}
+	return AppendLabelBinary(dst, label)
+}
+
+// AppendLabelBinary appends a label to dst in the binary format used by ToBinary.
+func AppendLabelBinary(dst []byte, label string) ([]byte, error) {
+	if len(label) > 65535 {
+		return dst, NewLabelTooLongError(len(label), 65535)
+	}
+	dst = binary.BigEndian.AppendUint16(dst, uint16(len(label)))
+	return append(dst, label...), nil
+}
+
+// AppendBinaryAs appends the binary form of an enum value to dst using the given encoding.
+func AppendBinaryAs[T Integer](t *Table[T], dst []byte, v T, enc BinaryEncoding, fingerprint byte) ([]byte, error) {
+	if enc == BinaryLabel {
+		return AppendBinary(t, dst, v)
+	}
+	if !t.Contains(v) {
+		return dst, t.RangeError(v)
+	}
+
+	if enc == BinaryVarintFingerprint {
+		dst = append(dst, binaryFingerprintMarker, fingerprint)
+	} else {
+		dst = append(dst, binaryVarintMarker)
+	}
+	var zero T
+	if zero-1 < zero {
+		return binary.AppendVarint(dst, int64(v)), nil
+	}
+	return binary.AppendUvarint(dst, uint64(v)), nil
+}
+
+// ReadBinaryFrame reads one binary-encoded enum value from r, in any encoding,
+// without reading past it. It returns io.EOF if r is empty.
+// Labels of 65024 bytes or more cannot be read, since their length prefix
+// starts like a varint marker.
+func ReadBinaryFrame(r io.Reader) ([]byte, error) {
+	var head [2]byte
+	if n, err := io.ReadFull(r, head[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, NewBinaryDataTooShortError(2, n)
+		}
+		return nil, err
+	}
+
+	if head[0] == binaryVarintMarker || head[0] == binaryFingerprintMarker {
+		frame := append(make([]byte, 0, maxVarintBinaryLen), head[:]...)
+		more := head[0] == binaryFingerprintMarker || head[1]&0x80 != 0
+		for more && len(frame) < maxVarintBinaryLen {
+			var b [1]byte
+			if _, err := io.ReadFull(r, b[:]); err != nil {
+				return nil, NewBinaryDataTruncatedError(len(frame)+1, len(frame))
+			}
+			frame = append(frame, b[0])
+			more = b[0]&0x80 != 0
+		}
+		return frame, nil
+	}
+
+	length := int(binary.BigEndian.Uint16(head[:]))
+	frame := make([]byte, 2+length)
+	copy(frame, head[:])
+	if n, err := io.ReadFull(r, frame[2:]); err != nil {
+		return nil, NewBinaryDataTruncatedError(2+length, 2+n)
+	}
+	return frame, nil
+}
+
+// appendInteger appends any integer kind in base 10.
+func appendInteger[T Integer](dst []byte, v T) []byte {
+	if v < 0 {
+		return strconv.AppendInt(dst, int64(v), 10)
+	}
+	return strconv.AppendUint(dst, uint64(v), 10)
+}
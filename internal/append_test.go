@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+// TestAppendJSONString tests that strings are escaped like json.Marshal
+func TestAppendJSONString(t *testing.T) {
+	for _, s := range []string{"", "active", `say "hi"`, `back\slash`, "tab\t", "<b>&</b>", "café", " ", "\xff"} {
+		expected, _ := json.Marshal(s)
+		got := AppendJSONString([]byte("x"), s)
+		if !bytes.Equal(got, append([]byte("x"), expected...)) {
+			t.Errorf("AppendJSONString(%q) = %s, want x%s", s, got, expected)
+		}
+	}
+}
+
+// TestAppendMatchesTo tests that the append functions write what the To functions return
+func TestAppendMatchesTo(t *testing.T) {
+	table := DenseTable[int]([]string{"low", "high"})
+	prefix := []byte("prefix")
+
+	for _, v := range []int{0, 1, 7} {
+		text, errTo := ToText(table, v)
+		got, errAppend := AppendText(table, prefix, v)
+		if (errTo == nil) != (errAppend == nil) || (errTo == nil && !bytes.Equal(got[len(prefix):], text)) {
+			t.Errorf("AppendText(%d) = %q (%v), want %q (%v)", v, got, errAppend, text, errTo)
+		}
+
+		jsonData, errTo := ToJSON(table, v)
+		got, errAppend = AppendJSON(table, prefix, v)
+		if (errTo == nil) != (errAppend == nil) || (errTo == nil && !bytes.Equal(got[len(prefix):], jsonData)) {
+			t.Errorf("AppendJSON(%d) = %s (%v), want %s (%v)", v, got, errAppend, jsonData, errTo)
+		}
+
+		for _, enc := range []BinaryEncoding{BinaryLabel, BinaryVarint} {
+			bin, errTo := ToBinaryAs(table, v, enc, 0)
+			got, errAppend := AppendBinaryAs(table, prefix, v, enc, 0)
+			if (errTo == nil) != (errAppend == nil) || (errTo == nil && !bytes.Equal(got[len(prefix):], bin)) {
+				t.Errorf("AppendBinaryAs(%d, %d) = %v (%v), want %v (%v)", v, enc, got, errAppend, bin, errTo)
+			}
+		}
+	}
+
+	if got, err := AppendJSONAs(table, nil, 1, RepresentNumber); err != nil || string(got) != "1" {
+		t.Errorf("expected 1, got %s (err %v)", got, err)
+	}
+}
+
+// TestReadBinaryFrame tests reading consecutive values from a stream
+func TestReadBinaryFrame(t *testing.T) {
+	table := DenseTable[int]([]string{"low", "high"})
+	var stream []byte
+	stream, _ = AppendBinaryAs(table, stream, 1, BinaryLabel, 0)
+	stream, _ = AppendBinaryAs(table, stream, 0, BinaryVarint, 0)
+	stream, _ = AppendBinaryAs(table, stream, 1, BinaryVarintFingerprint, 9)
+
+	r := bytes.NewReader(stream)
+	for i, want := range []int{1, 0, 1} {
+		frame, err := ReadBinaryFrame(r)
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if v, err := FromBinaryAs(table, frame, 9); err != nil || v != want {
+			t.Errorf("frame %d: expected %d, got %d (err %v)", i, want, v, err)
+		}
+	}
+	if _, err := ReadBinaryFrame(r); err != io.EOF {
+		t.Errorf("expected io.EOF at the end, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"one byte", []byte{0}, &ErrBinaryDataTooShort{}},
+		{"truncated label", []byte{0, 5, 'l', 'o'}, &ErrBinaryDataTruncated{}},
+		{"truncated varint", []byte{0xff, 0x80}, &ErrBinaryDataTruncated{}},
+		{"missing varint", []byte{0xfe, 9}, &ErrBinaryDataTruncated{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadBinaryFrame(bytes.NewReader(tt.data)); !errors.Is(err, tt.err) {
+				t.Errorf("expected %T, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -1,31 +1,31 @@
 package internal
 
 // CacheBuilder helps build cached data structures for enum optimization
-type CacheBuilder[T ~int] struct {
+type CacheBuilder[T Integer] struct {
 	labels []string
+	values []T
 }
 
 // NewCacheBuilder creates a new cache builder
-func NewCacheBuilder[T ~int](labels []string) *CacheBuilder[T] {
+func NewCacheBuilder[T Integer](labels []string) *CacheBuilder[T] {
 	return &CacheBuilder[T]{labels: labels}
 }
 
+// NewCacheBuilderWithValues creates a new cache builder for explicit values.
+// values[i] is the value of labels[i]; both slices must have the same length.
+func NewCacheBuilderWithValues[T Integer](labels []string, values []T) *CacheBuilder[T] {
+	return &CacheBuilder[T]{labels: labels, values: values}
+}
+
 // BuildAllValues creates a pre-computed slice of all enum values
 func (cb *CacheBuilder[T]) BuildAllValues() []T {
 	allVals := make([]T, len(cb.labels))
+	if cb.values != nil {
+		copy(allVals, cb.values)
+		return allVals
+	}
 	for i := range cb.labels {
 		allVals[i] = T(i)
 	}
 	return allVals
 }
-
-// BuildLookupMap creates a lookup map for string-to-value conversion
-func (cb *CacheBuilder[T]) BuildLookupMap() map[string]T {
-	return BuildLabelMap[T](cb.labels)
-}
-
-// ShouldUseCachedLookup determines if a cached lookup map should be used
-// based on the number of labels and expected usage patterns
-func (cb *CacheBuilder[T]) ShouldUseCachedLookup() bool {
-	return len(cb.labels) > LookupThreshold
-}
@@ -55,79 +55,6 @@ func TestBuildAllValues(t *testing.T) {
 	}
 }
 
-// TestBuildLookupMap tests building lookup map cache
-func TestBuildLookupMap(t *testing.T) {
-	labels := []string{"apple", "banana", "cherry"}
-	builder := NewCacheBuilder[int](labels)
-
-	lookupMap := builder.BuildLookupMap()
-
-	// Test that all labels are correctly mapped
-	for i, label := range labels {
-		if val, ok := lookupMap[label]; !ok {
-			t.Errorf("label %q not found in lookup map", label)
-		} else if val != i {
-			t.Errorf("expected %d for label %q, got %d", i, label, val)
-		}
-	}
-
-	// Test that map has correct size
-	if len(lookupMap) != len(labels) {
-		t.Errorf("expected map size %d, got %d", len(labels), len(lookupMap))
-	}
-
-	// Test that non-existent label is not in map
-	if _, ok := lookupMap["nonexistent"]; ok {
-		t.Error("unexpected entry for nonexistent label")
-	}
-}
-
-// TestShouldUseCachedLookup tests lookup method decision
-func TestShouldUseCachedLookup(t *testing.T) {
-	tests := []struct {
-		name     string
-		labels   []string
-		expected bool
-	}{
-		{
-			name:     "empty labels",
-			labels:   []string{},
-			expected: false,
-		},
-		{
-			name:     "small enum",
-			labels:   []string{"a", "b", "c"},
-			expected: false,
-		},
-		{
-			name:     "at threshold",
-			labels:   generateTestLabels(DefaultLookupThreshold),
-			expected: false,
-		},
-		{
-			name:     "above threshold",
-			labels:   generateTestLabels(DefaultLookupThreshold + 1),
-			expected: true,
-		},
-		{
-			name:     "large enum",
-			labels:   generateTestLabels(50),
-			expected: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			builder := NewCacheBuilder[int](tt.labels)
-			result := builder.ShouldUseCachedLookup()
-
-			if result != tt.expected {
-				t.Errorf("expected %v, got %v", tt.expected, result)
-			}
-		})
-	}
-}
-
 // TestCacheBuilderWithCustomTypes tests cache builder with custom integer types
 func TestCacheBuilderWithCustomTypes(t *testing.T) {
 	type CustomInt int
@@ -142,17 +69,6 @@ func TestCacheBuilderWithCustomTypes(t *testing.T) {
 	if !reflect.DeepEqual(allVals, expected) {
 		t.Errorf("expected %v, got %v", expected, allVals)
 	}
-
-	// Test BuildLookupMap with custom type
-	lookupMap := builder.BuildLookupMap()
-
-	for i, label := range labels {
-		if val, ok := lookupMap[label]; !ok {
-			t.Errorf("label %q not found in lookup map", label)
-		} else if val != CustomInt(i) {
-			t.Errorf("expected %d for label %q, got %d", i, label, val)
-		}
-	}
 }
 
 // TestCacheBuilderConsistency tests that cache builder produces consistent results
@@ -170,22 +86,6 @@ func TestCacheBuilderConsistency(t *testing.T) {
 	if !reflect.DeepEqual(allVals1, allVals2) {
 		t.Errorf("inconsistent AllValues: %v vs %v", allVals1, allVals2)
 	}
-
-	// Test LookupMap consistency
-	map1 := builder1.BuildLookupMap()
-	map2 := builder2.BuildLookupMap()
-
-	if !reflect.DeepEqual(map1, map2) {
-		t.Errorf("inconsistent LookupMaps: %v vs %v", map1, map2)
-	}
-
-	// Test ShouldUseCachedLookup consistency
-	should1 := builder1.ShouldUseCachedLookup()
-	should2 := builder2.ShouldUseCachedLookup()
-
-	if should1 != should2 {
-		t.Errorf("inconsistent ShouldUseCachedLookup: %v vs %v", should1, should2)
-	}
 }
 
 // TestCacheBuilderMemoryEfficiency tests that cache builder doesn't modify original labels
@@ -200,8 +100,6 @@ func TestCacheBuilderMemoryEfficiency(t *testing.T) {
 
 	// Build caches
 	_ = builder.BuildAllValues()
-	_ = builder.BuildLookupMap()
-	_ = builder.ShouldUseCachedLookup()
 
 	// Verify original labels weren't modified
 	if !reflect.DeepEqual(labelsCopy, originalLabels) {
@@ -218,27 +116,22 @@ func TestEmptyLabelsCacheBuilder(t *testing.T) {
 	if len(allVals) != 0 {
 		t.Errorf("expected empty slice, got %v", allVals)
 	}
+}
 
-	// Test BuildLookupMap
-	lookupMap := builder.BuildLookupMap()
-	if len(lookupMap) != 0 {
-		t.Errorf("expected empty map, got %v", lookupMap)
-	}
+// TestCacheBuilderWithValues tests cache building with explicit values
+func TestCacheBuilderWithValues(t *testing.T) {
+	labels := []string{"active", "suspended", "deleted"}
+	values := []int{10, 20, 99}
+	builder := NewCacheBuilderWithValues(labels, values)
 
-	// Test ShouldUseCachedLookup
-	if builder.ShouldUseCachedLookup() {
-		t.Error("expected false for empty labels")
+	allVals := builder.BuildAllValues()
+	if !reflect.DeepEqual(allVals, values) {
+		t.Errorf("expected values %v, got %v", values, allVals)
 	}
-}
 
-// generateTestLabels is a helper function to create test labels
-func generateTestLabels(count int) []string {
-	labels := make([]string, count)
-	for i := 0; i < count; i++ {
-		labels[i] = string(rune('a' + (i % 26)))
-		if i >= 26 {
-			labels[i] += string(rune('0' + (i / 26)))
-		}
+	// The returned slice must not share memory with the input
+	allVals[0] = 0
+	if values[0] != 10 {
+		t.Error("modifying built values affected the input slice")
 	}
-	return labels
 }
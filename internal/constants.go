@@ -5,6 +5,6 @@ const (
 	// InvalidLabel is the default label used for invalid enum values
 	InvalidLabel = "Invalid"
 
-	// DefaultLookupThreshold defines when to switch from linear to map-based lookup
-	DefaultLookupThreshold = 10
+	// FlagSeparator separates flag labels in the text form of a flag set
+	FlagSeparator = "|"
 )
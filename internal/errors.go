@@ -5,16 +5,28 @@ import (
 )
 
 // ErrInvalidEnumValue is returned when trying to unmarshal an invalid enum value.
+// Line and Column locate the value in YAML documents, and are 0 otherwise.
+// Pointer is the JSON pointer of the value when decoded with encoding/json/v2;
+// it is left out of the message, which json/v2 already prefixes with it.
 type ErrInvalidEnumValue struct {
 	Value       string
 	ValidValues []string
+	Line        int
+	Column      int
+	Pointer     string
 }
 
 func (e *ErrInvalidEnumValue) Error() string {
+	var msg string
 	if len(e.ValidValues) == 0 {
-		return fmt.Sprintf("invalid enum value: %q (no valid values available)", e.Value)
+		msg = fmt.Sprintf("invalid enum value: %q (no valid values available)", e.Value)
+	} else {
+		msg = fmt.Sprintf("invalid enum value: %q (valid values: %v)", e.Value, e.ValidValues)
 	}
-	return fmt.Sprintf("invalid enum value: %q (valid values: %v)", e.Value, e.ValidValues)
+	if e.Line > 0 {
+		msg += fmt.Sprintf(" at line %d, column %d", e.Line, e.Column)
+	}
+	return msg
 }
 
 // Is implements the errors.Is interface for error comparison.
@@ -71,6 +83,121 @@ func (e *ErrLabelTooLong) Is(target error) bool {
 	return ok
 }
 
+// ErrTooManyLabels is returned when an enum has more labels than its value type can represent.
+type ErrTooManyLabels struct {
+	Count int
+	Max   int
+}
+
+func (e *ErrTooManyLabels) Error() string {
+	return fmt.Sprintf("too many labels: %d (value type holds at most %d)", e.Count, e.Max)
+}
+
+// Is implements the errors.Is interface for error comparison.
+func (e *ErrTooManyLabels) Is(target error) bool {
+	_, ok := target.(*ErrTooManyLabels)
+	return ok
+}
+
+// ErrRegistryConflict is returned when a type is registered again with a different definition.
+type ErrRegistryConflict struct {
+	Type     string
+	Existing []string
+	Labels   []string
+}
+
+func (e *ErrRegistryConflict) Error() string {
+	return fmt.Sprintf("registry conflict for %s: registered labels %v, got %v", e.Type, e.Existing, e.Labels)
+}
+
+// Is implements the errors.Is interface for error comparison.
+func (e *ErrRegistryConflict) Is(target error) bool {
+	_, ok := target.(*ErrRegistryConflict)
+	return ok
+}
+
+// ErrRegistrySealed is returned when registering a new definition in a sealed registry.
+type ErrRegistrySealed struct {
+	Type string
+}
+
+func (e *ErrRegistrySealed) Error() string {
+	return fmt.Sprintf("registry is sealed: cannot register %s", e.Type)
+}
+
+// Is implements the errors.Is interface for error comparison.
+func (e *ErrRegistrySealed) Is(target error) bool {
+	_, ok := target.(*ErrRegistrySealed)
+	return ok
+}
+
+// ErrBinaryFingerprintMismatch is returned when binary data was written by a different version of the enum.
+type ErrBinaryFingerprintMismatch struct {
+	Expected byte
+	Actual   byte
+}
+
+func (e *ErrBinaryFingerprintMismatch) Error() string {
+	return fmt.Sprintf("binary enum fingerprint mismatch: expected %#02x, got %#02x", e.Expected, e.Actual)
+}
+
+// Is implements the errors.Is interface for error comparison.
+func (e *ErrBinaryFingerprintMismatch) Is(target error) bool {
+	_, ok := target.(*ErrBinaryFingerprintMismatch)
+	return ok
+}
+
+// ErrEnumNotConfigured is returned when a wrapper has no enum and none is registered for its type.
+type ErrEnumNotConfigured struct {
+	Type string
+}
+
+func (e *ErrEnumNotConfigured) Error() string {
+	return fmt.Sprintf("enum not configured: no enum set or registered for %s", e.Type)
+}
+
+// Is implements the errors.Is interface for error comparison.
+func (e *ErrEnumNotConfigured) Is(target error) bool {
+	_, ok := target.(*ErrEnumNotConfigured)
+	return ok
+}
+
+// ErrRegistryKindMismatch is returned when a wrapper resolves its enum from a
+// registry entry of another kind, such as flags registered for a Wrapper type.
+type ErrRegistryKindMismatch struct {
+	Type       string
+	Registered string
+	Expected   string
+}
+
+func (e *ErrRegistryKindMismatch) Error() string {
+	return fmt.Sprintf("registry kind mismatch: %s is registered as %s, expected %s", e.Type, e.Registered, e.Expected)
+}
+
+// Is implements the errors.Is interface for error comparison.
+func (e *ErrRegistryKindMismatch) Is(target error) bool {
+	_, ok := target.(*ErrRegistryKindMismatch)
+	return ok
+}
+
+// ErrValueOutOfRange is returned when marshalling a value that is not a member of the enum.
+// Min and Max are the smallest and largest members.
+type ErrValueOutOfRange struct {
+	Value string
+	Min   string
+	Max   string
+}
+
+func (e *ErrValueOutOfRange) Error() string {
+	return fmt.Sprintf("enum value %s out of range: not a member of [%s, %s]", e.Value, e.Min, e.Max)
+}
+
+// Is implements the errors.Is interface for error comparison.
+func (e *ErrValueOutOfRange) Is(target error) bool {
+	_, ok := target.(*ErrValueOutOfRange)
+	return ok
+}
+
 // NewInvalidEnumValueError creates a new ErrInvalidEnumValue.
 func NewInvalidEnumValueError(value string, validValues []string) *ErrInvalidEnumValue {
 	// Create a copy of validValues to avoid external modifications
@@ -83,6 +210,14 @@ func NewInvalidEnumValueError(value string, validValues []string) *ErrInvalidEnu
 	}
 }
 
+// NewInvalidEnumValueErrorAt creates a new ErrInvalidEnumValue located at a line and column.
+func NewInvalidEnumValueErrorAt(value string, validValues []string, line, column int) *ErrInvalidEnumValue {
+	err := NewInvalidEnumValueError(value, validValues)
+	err.Line = line
+	err.Column = column
+	return err
+}
+
 // NewBinaryDataTooShortError creates a new ErrBinaryDataTooShort.
 func NewBinaryDataTooShortError(expected, actual int) *ErrBinaryDataTooShort {
 	return &ErrBinaryDataTooShort{
@@ -106,3 +241,56 @@ func NewLabelTooLongError(length, maxLength int) *ErrLabelTooLong {
 		MaxLength: maxLength,
 	}
 }
+
+// NewTooManyLabelsError creates a new ErrTooManyLabels.
+func NewTooManyLabelsError(count, maxLabels int) *ErrTooManyLabels {
+	return &ErrTooManyLabels{
+		Count: count,
+		Max:   maxLabels,
+	}
+}
+
+// NewRegistryConflictError creates a new ErrRegistryConflict.
+func NewRegistryConflictError(typeName string, existing, labels []string) *ErrRegistryConflict {
+	return &ErrRegistryConflict{
+		Type:     typeName,
+		Existing: append([]string(nil), existing...),
+		Labels:   append([]string(nil), labels...),
+	}
+}
+
+// NewRegistrySealedError creates a new ErrRegistrySealed.
+func NewRegistrySealedError(typeName string) *ErrRegistrySealed {
+	return &ErrRegistrySealed{Type: typeName}
+}
+
+// NewBinaryFingerprintMismatchError creates a new ErrBinaryFingerprintMismatch.
+func NewBinaryFingerprintMismatchError(expected, actual byte) *ErrBinaryFingerprintMismatch {
+	return &ErrBinaryFingerprintMismatch{
+		Expected: expected,
+		Actual:   actual,
+	}
+}
+
+// NewEnumNotConfiguredError creates a new ErrEnumNotConfigured.
+func NewEnumNotConfiguredError(typeName string) *ErrEnumNotConfigured {
+	return &ErrEnumNotConfigured{Type: typeName}
+}
+
+// NewRegistryKindMismatchError creates a new ErrRegistryKindMismatch.
+func NewRegistryKindMismatchError(typeName, registered, expected string) *ErrRegistryKindMismatch {
+	return &ErrRegistryKindMismatch{
+		Type:       typeName,
+		Registered: registered,
+		Expected:   expected,
+	}
+}
+
+// NewValueOutOfRangeError creates a new ErrValueOutOfRange.
+func NewValueOutOfRangeError(value, minValue, maxValue string) *ErrValueOutOfRange {
+	return &ErrValueOutOfRange{
+		Value: value,
+		Min:   minValue,
+		Max:   maxValue,
+	}
+}
@@ -131,3 +131,117 @@ func TestErrorTypeComparison(t *testing.T) {
 		t.Error("Two ErrLabelTooLong instances should be considered equal via errors.Is")
 	}
 }
+
+// TestErrTooManyLabels tests the ErrTooManyLabels error type.
+func TestErrTooManyLabels(t *testing.T) {
+	err := NewTooManyLabelsError(300, 128)
+
+	expectedMsg := "too many labels: 300 (value type holds at most 128)"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+
+	if !errors.Is(err, &ErrTooManyLabels{}) {
+		t.Error("expected errors.Is to match *ErrTooManyLabels")
+	}
+}
+
+// TestErrRegistryConflict tests the ErrRegistryConflict error type.
+func TestErrRegistryConflict(t *testing.T) {
+	existing := []string{"a", "b"}
+	err := NewRegistryConflictError("orders.Status", existing, []string{"x"})
+
+	expectedMsg := "registry conflict for orders.Status: registered labels [a b], got [x]"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+
+	if !errors.Is(err, &ErrRegistryConflict{}) {
+		t.Error("expected errors.Is to match *ErrRegistryConflict")
+	}
+
+	existing[0] = "modified"
+	if err.Existing[0] != "a" {
+		t.Error("error's Existing should not be affected by external modifications")
+	}
+}
+
+// TestErrRegistrySealed tests the ErrRegistrySealed error type.
+func TestErrRegistrySealed(t *testing.T) {
+	err := NewRegistrySealedError("orders.Status")
+
+	expectedMsg := "registry is sealed: cannot register orders.Status"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+
+	if !errors.Is(err, &ErrRegistrySealed{}) {
+		t.Error("expected errors.Is to match *ErrRegistrySealed")
+	}
+}
+
+// TestErrBinaryFingerprintMismatch tests the ErrBinaryFingerprintMismatch error type.
+func TestErrBinaryFingerprintMismatch(t *testing.T) {
+	err := NewBinaryFingerprintMismatchError(0x1a, 0x2b)
+
+	expectedMsg := "binary enum fingerprint mismatch: expected 0x1a, got 0x2b"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+
+	if !errors.Is(err, &ErrBinaryFingerprintMismatch{}) {
+		t.Error("expected errors.Is to match *ErrBinaryFingerprintMismatch")
+	}
+}
+
+// TestErrEnumNotConfigured tests the ErrEnumNotConfigured error type.
+func TestErrEnumNotConfigured(t *testing.T) {
+	err := NewEnumNotConfiguredError("orders.Status")
+
+	expectedMsg := "enum not configured: no enum set or registered for orders.Status"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+
+	if !errors.Is(err, &ErrEnumNotConfigured{}) {
+		t.Error("expected errors.Is to match *ErrEnumNotConfigured")
+	}
+}
+
+// TestErrRegistryKindMismatch tests the ErrRegistryKindMismatch error type.
+func TestErrRegistryKindMismatch(t *testing.T) {
+	err := NewRegistryKindMismatchError("acl.Perm", "flags", "enum")
+
+	expectedMsg := "registry kind mismatch: acl.Perm is registered as flags, expected enum"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+
+	if !errors.Is(err, &ErrRegistryKindMismatch{}) {
+		t.Error("expected errors.Is to match *ErrRegistryKindMismatch")
+	}
+}
+
+// TestErrValueOutOfRange tests the ErrValueOutOfRange error type.
+func TestErrValueOutOfRange(t *testing.T) {
+	err := NewValueOutOfRangeError("7", "0", "3")
+
+	expectedMsg := "enum value 7 out of range: not a member of [0, 3]"
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+
+	if !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Error("expected errors.Is to match *ErrValueOutOfRange")
+	}
+}
+
+// TestErrInvalidEnumValueAt tests the position of an ErrInvalidEnumValue.
+func TestErrInvalidEnumValueAt(t *testing.T) {
+	err := NewInvalidEnumValueErrorAt("huge", []string{"small", "large"}, 3, 9)
+
+	expectedMsg := `invalid enum value: "huge" (valid values: [small large]) at line 3, column 9`
+	if err.Error() != expectedMsg {
+		t.Errorf("expected error message %q, got %q", expectedMsg, err.Error())
+	}
+}
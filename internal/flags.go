@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// FlagBits creates the power-of-two values for count flags (1, 2, 4, ...).
+func FlagBits[T Integer](count int) []T {
+	bits := make([]T, count)
+	for i := range bits {
+		bits[i] = T(1) << i
+	}
+	return bits
+}
+
+// ValidateFlagCapacity checks that count flags fit in the positive bits of T.
+func ValidateFlagCapacity[T Integer](count int) error {
+	if maxFlags := MaxFlags[T](); count > maxFlags {
+		return NewTooManyLabelsError(count, maxFlags)
+	}
+	return nil
+}
+
+// MaxFlags returns the number of distinct positive bits of T.
+// The sign bit of signed types is not used for flags.
+func MaxFlags[T Integer]() int {
+	n := 0
+	for bit := T(1); bit > 0; bit <<= 1 {
+		n++
+	}
+	return n
+}
+
+// FlagMask returns the union of all flags in the table.
+func FlagMask[T Integer](t *Table[T]) T {
+	var mask T
+	for _, bit := range t.Values() {
+		mask |= bit
+	}
+	return mask
+}
+
+// SplitFlags returns the labels of the flags set in v, in table order.
+// It returns an error if v contains bits that do not belong to any flag.
+func SplitFlags[T Integer](t *Table[T], v T) ([]string, error) {
+	if unknown := v &^ FlagMask(t); unknown != 0 {
+		return nil, NewInvalidEnumValueError(formatInteger(unknown), t.Labels())
+	}
+
+	names := make([]string, 0, len(t.Values()))
+	for i, bit := range t.Values() {
+		if v&bit == bit {
+			names = append(names, t.Labels()[i])
+		}
+	}
+	return names, nil
+}
+
+// ParseFlags combines the flags named by labels.
+func ParseFlags[T Integer](t *Table[T], labels []string) (T, error) {
+	var v T
+	for _, label := range labels {
+		bit, found := t.Lookup(label)
+		if !found {
+			var zero T
+			return zero, NewInvalidEnumValueError(label, t.Labels())
+		}
+		v |= bit
+	}
+	return v, nil
+}
+
+// FlagsToText serializes a flag set as labels joined by FlagSeparator.
+func FlagsToText[T Integer](t *Table[T], v T) ([]byte, error) {
+	names, err := SplitFlags(t, v)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.Join(names, FlagSeparator)), nil
+}
+
+// FlagsFromText deserializes labels joined by FlagSeparator into a flag set.
+// Empty text is the empty set.
+func FlagsFromText[T Integer](t *Table[T], text []byte) (T, error) {
+	if len(text) == 0 {
+		var zero T
+		return zero, nil
+	}
+	return ParseFlags(t, strings.Split(string(text), FlagSeparator))
+}
+
+// FlagsToJSON serializes a flag set as a JSON array of labels.
+func FlagsToJSON[T Integer](t *Table[T], v T) ([]byte, error) {
+	names, err := SplitFlags(t, v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(names)
+}
+
+// FlagsFromJSON deserializes a JSON array of labels into a flag set.
+func FlagsFromJSON[T Integer](t *Table[T], b []byte) (T, error) {
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		var zero T
+		return zero, err
+	}
+	return ParseFlags(t, names)
+}
+
+// FlagsToYAML serializes a flag set as a YAML sequence of labels.
+func FlagsToYAML[T Integer](t *Table[T], v T) (any, error) {
+	return SplitFlags(t, v)
+}
+
+// FlagsToBinary serializes a flag set into binary.
+// The format is a 2-byte count followed by each label as a 2-byte length
+// and its bytes (all big-endian). Labels are used instead of the raw bits
+// so that the encoding stays valid if flags are reordered.
+func FlagsToBinary[T Integer](t *Table[T], v T) ([]byte, error) {
+	names, err := SplitFlags(t, v)
+	if err != nil {
+		return nil, err
+	}
+
+	size := 2
+	for _, name := range names {
+		if len(name) > 65535 {
+			return nil, NewLabelTooLongError(len(name), 65535)
+		}
+		size += 2 + len(name)
+	}
+
+	result := make([]byte, 2, size)
+	binary.BigEndian.PutUint16(result, uint16(len(names)))
+	for _, name := range names {
+		result = binary.BigEndian.AppendUint16(result, uint16(len(name)))
+		result = append(result, name...)
+	}
+	return result, nil
+}
+
+// FlagsFromBinary deserializes binary produced by FlagsToBinary into a flag set.
+func FlagsFromBinary[T Integer](t *Table[T], data []byte) (T, error) {
+	var zero T
+
+	if len(data) < 2 {
+		return zero, NewBinaryDataTooShortError(2, len(data))
+	}
+
+	count := int(binary.BigEndian.Uint16(data[0:2]))
+	names := make([]string, 0, count)
+	offset := 2
+	for range count {
+		if len(data) < offset+2 {
+			return zero, NewBinaryDataTruncatedError(offset+2, len(data))
+		}
+		length := int(binary.BigEndian.Uint16(data[offset : offset+2]))
+		offset += 2
+		if len(data) < offset+length {
+			return zero, NewBinaryDataTruncatedError(offset+length, len(data))
+		}
+		names = append(names, string(data[offset:offset+length]))
+		offset += length
+	}
+
+	return ParseFlags(t, names)
+}
+
+// FlagsToSQLValue serializes a flag set for SQL storage using the text form.
+func FlagsToSQLValue[T Integer](t *Table[T], v T) (driver.Value, error) {
+	text, err := FlagsToText(t, v)
+	if err != nil {
+		return nil, err
+	}
+	return string(text), nil
+}
+
+// FlagsFromSQLValue deserializes an SQL text value into a flag set.
+func FlagsFromSQLValue[T Integer](t *Table[T], src any) (T, error) {
+	var zero T
+
+	switch v := src.(type) {
+	case nil:
+		// SQL NULL maps to the empty set
+		return zero, nil
+	case string:
+		return FlagsFromText(t, []byte(v))
+	case []byte:
+		return FlagsFromText(t, v)
+	default:
+		return zero, NewInvalidEnumValueError("non-string SQL value", t.Labels())
+	}
+}
+
+// formatInteger formats any integer kind in base 10.
+func formatInteger[T Integer](v T) string {
+	if v < 0 {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatUint(uint64(v), 10)
+}
@@ -0,0 +1,266 @@
+package internal
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func newPermissionTable() *Table[int] {
+	labels := []string{"read", "write", "exec"}
+	return NewTable(labels, FlagBits[int](len(labels)))
+}
+
+// TestFlagBits tests power-of-two value generation
+func TestFlagBits(t *testing.T) {
+	expected := []uint8{1, 2, 4, 8}
+	if bits := FlagBits[uint8](4); !reflect.DeepEqual(bits, expected) {
+		t.Errorf("expected %v, got %v", expected, bits)
+	}
+}
+
+// TestMaxFlags tests the number of usable bits per integer kind
+func TestMaxFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      int
+		expected int
+	}{
+		{name: "int8", got: MaxFlags[int8](), expected: 7},
+		{name: "uint8", got: MaxFlags[uint8](), expected: 8},
+		{name: "int32", got: MaxFlags[int32](), expected: 31},
+		{name: "uint64", got: MaxFlags[uint64](), expected: 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, tt.got)
+			}
+		})
+	}
+
+	if err := ValidateFlagCapacity[int8](7); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := ValidateFlagCapacity[int8](8); !errors.Is(err, &ErrTooManyLabels{}) {
+		t.Errorf("expected ErrTooManyLabels, got %v", err)
+	}
+}
+
+// TestSplitFlags tests splitting a flag set into labels
+func TestSplitFlags(t *testing.T) {
+	table := newPermissionTable()
+
+	tests := []struct {
+		name        string
+		value       int
+		expected    []string
+		expectError bool
+	}{
+		{name: "empty set", value: 0, expected: []string{}},
+		{name: "single flag", value: 2, expected: []string{"write"}},
+		{name: "combined flags", value: 5, expected: []string{"read", "exec"}},
+		{name: "all flags", value: 7, expected: []string{"read", "write", "exec"}},
+		{name: "unknown bit", value: 9, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SplitFlags(table, tt.value)
+			if tt.expectError {
+				var invalidErr *ErrInvalidEnumValue
+				if !errors.As(err, &invalidErr) {
+					t.Fatalf("expected ErrInvalidEnumValue, got %v", err)
+				}
+				if invalidErr.Value != "8" {
+					t.Errorf("expected unknown bits %q, got %q", "8", invalidErr.Value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFlagsText tests text serialization of flag sets
+func TestFlagsText(t *testing.T) {
+	table := newPermissionTable()
+
+	text, err := FlagsToText(table, 3)
+	if err != nil {
+		t.Fatalf("FlagsToText failed: %v", err)
+	}
+	if string(text) != "read|write" {
+		t.Errorf("expected %q, got %q", "read|write", text)
+	}
+
+	val, err := FlagsFromText(table, []byte("exec|read"))
+	if err != nil {
+		t.Fatalf("FlagsFromText failed: %v", err)
+	}
+	if val != 5 {
+		t.Errorf("expected 5, got %d", val)
+	}
+
+	val, err = FlagsFromText(table, nil)
+	if err != nil || val != 0 {
+		t.Errorf("expected empty set, got %d (err %v)", val, err)
+	}
+
+	_, err = FlagsFromText(table, []byte("read|delete"))
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) || invalidErr.Value != "delete" {
+		t.Errorf("expected ErrInvalidEnumValue for %q, got %v", "delete", err)
+	}
+}
+
+// TestFlagsJSON tests JSON serialization of flag sets
+func TestFlagsJSON(t *testing.T) {
+	table := newPermissionTable()
+
+	data, err := FlagsToJSON(table, 6)
+	if err != nil {
+		t.Fatalf("FlagsToJSON failed: %v", err)
+	}
+	if string(data) != `["write","exec"]` {
+		t.Errorf("expected %s, got %s", `["write","exec"]`, data)
+	}
+
+	data, err = FlagsToJSON(table, 0)
+	if err != nil {
+		t.Fatalf("FlagsToJSON failed: %v", err)
+	}
+	if string(data) != `[]` {
+		t.Errorf("expected [], got %s", data)
+	}
+
+	val, err := FlagsFromJSON(table, []byte(`["read","exec"]`))
+	if err != nil {
+		t.Fatalf("FlagsFromJSON failed: %v", err)
+	}
+	if val != 5 {
+		t.Errorf("expected 5, got %d", val)
+	}
+
+	if _, err := FlagsFromJSON(table, []byte(`"read"`)); err == nil {
+		t.Error("expected error for non-array JSON")
+	}
+	if _, err := FlagsFromJSON(table, []byte(`["admin"]`)); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+}
+
+// TestFlagsYAML tests YAML serialization of flag sets
+func TestFlagsYAML(t *testing.T) {
+	table := newPermissionTable()
+
+	yamlValue, err := FlagsToYAML(table, 3)
+	if err != nil {
+		t.Fatalf("FlagsToYAML failed: %v", err)
+	}
+	if !reflect.DeepEqual(yamlValue, []string{"read", "write"}) {
+		t.Errorf("expected [read write], got %v", yamlValue)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte("[write, exec]"), &node); err != nil {
+		t.Fatalf("yaml.Unmarshal failed: %v", err)
+	}
+	val, err := FlagsFromYAMLNode(table, node.Content[0])
+	if err != nil {
+		t.Fatalf("FlagsFromYAMLNode failed: %v", err)
+	}
+	if val != 6 {
+		t.Errorf("expected 6, got %d", val)
+	}
+}
+
+// TestFlagsBinary tests binary serialization of flag sets
+func TestFlagsBinary(t *testing.T) {
+	table := newPermissionTable()
+
+	data, err := FlagsToBinary(table, 5)
+	if err != nil {
+		t.Fatalf("FlagsToBinary failed: %v", err)
+	}
+	expected := []byte{0, 2, 0, 4, 'r', 'e', 'a', 'd', 0, 4, 'e', 'x', 'e', 'c'}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("expected %v, got %v", expected, data)
+	}
+
+	val, err := FlagsFromBinary(table, data)
+	if err != nil {
+		t.Fatalf("FlagsFromBinary failed: %v", err)
+	}
+	if val != 5 {
+		t.Errorf("expected 5, got %d", val)
+	}
+
+	// Reordered flags decode to the same set
+	reordered := NewTable([]string{"exec", "read", "write"}, []int{4, 1, 2})
+	if val, err := FlagsFromBinary(reordered, data); err != nil || val != 5 {
+		t.Errorf("expected 5 from reordered table, got %d (err %v)", val, err)
+	}
+
+	if _, err := FlagsFromBinary(table, []byte{0}); !errors.Is(err, &ErrBinaryDataTooShort{}) {
+		t.Errorf("expected ErrBinaryDataTooShort, got %v", err)
+	}
+	if _, err := FlagsFromBinary(table, []byte{0, 1, 0}); !errors.Is(err, &ErrBinaryDataTruncated{}) {
+		t.Errorf("expected ErrBinaryDataTruncated, got %v", err)
+	}
+	if _, err := FlagsFromBinary(table, []byte{0, 1, 0, 4, 'r'}); !errors.Is(err, &ErrBinaryDataTruncated{}) {
+		t.Errorf("expected ErrBinaryDataTruncated, got %v", err)
+	}
+}
+
+// TestFlagsSQLValue tests SQL serialization of flag sets
+func TestFlagsSQLValue(t *testing.T) {
+	table := newPermissionTable()
+
+	value, err := FlagsToSQLValue(table, 3)
+	if err != nil {
+		t.Fatalf("FlagsToSQLValue failed: %v", err)
+	}
+	if value != "read|write" {
+		t.Errorf("expected %q, got %v", "read|write", value)
+	}
+
+	tests := []struct {
+		name        string
+		src         any
+		expected    int
+		expectError bool
+	}{
+		{name: "string", src: "read|exec", expected: 5},
+		{name: "bytes", src: []byte("write"), expected: 2},
+		{name: "null", src: nil, expected: 0},
+		{name: "integer", src: int64(3), expectError: true},
+		{name: "unknown flag", src: "admin", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FlagsFromSQLValue(table, tt.src)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
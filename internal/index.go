@@ -0,0 +1,103 @@
+package internal
+
+// Limits used by NewLabelIndex to pick a lookup strategy.
+const (
+	// LinearIndexMax is the largest label count searched linearly.
+	LinearIndexMax = 4
+
+	// LengthIndexMaxLen is the longest label a length dispatch can hold.
+	LengthIndexMaxLen = 64
+
+	// LengthIndexMaxBucket is the most labels of one length a length dispatch accepts.
+	LengthIndexMaxBucket = 4
+)
+
+// LabelIndex finds the position of a label without allocating.
+// It picks a strategy from the shape of the labels:
+//   - up to LinearIndexMax labels, a linear scan;
+//   - when few labels share a length, a dispatch on the input length, so a
+//     lookup compares the input with at most LengthIndexMaxBucket labels
+//     and never hashes it;
+//   - otherwise, a map.
+type LabelIndex struct {
+	labels   []string
+	byLength [][]int
+	byLabel  map[string]int
+}
+
+// NewLabelIndex builds an index over labels.
+// When a label appears more than once, the first position wins.
+func NewLabelIndex(labels []string) *LabelIndex {
+	idx := &LabelIndex{labels: labels}
+	if len(labels) <= LinearIndexMax {
+		return idx
+	}
+	if byLength, ok := buildLengthDispatch(labels); ok {
+		idx.byLength = byLength
+		return idx
+	}
+	idx.byLabel = make(map[string]int, len(labels))
+	for i, label := range labels {
+		if _, exists := idx.byLabel[label]; !exists {
+			idx.byLabel[label] = i
+		}
+	}
+	return idx
+}
+
+// buildLengthDispatch groups label positions by label length, or reports
+// false if labels are too long or too many share a length.
+func buildLengthDispatch(labels []string) ([][]int, bool) {
+	longest := 0
+	for _, label := range labels {
+		if len(label) > LengthIndexMaxLen {
+			return nil, false
+		}
+		longest = max(longest, len(label))
+	}
+	byLength := make([][]int, longest+1)
+	for i, label := range labels {
+		bucket := byLength[len(label)]
+		if len(bucket) == LengthIndexMaxBucket {
+			return nil, false
+		}
+		byLength[len(label)] = append(bucket, i)
+	}
+	return byLength, true
+}
+
+// Find returns the position of label.
+func (idx *LabelIndex) Find(label string) (int, bool) {
+	return indexLookup(idx, label)
+}
+
+// FindBytes returns the position of a label held in a byte slice.
+func (idx *LabelIndex) FindBytes(label []byte) (int, bool) {
+	return indexLookup(idx, label)
+}
+
+// indexLookup implements Find and FindBytes.
+func indexLookup[S string | []byte](idx *LabelIndex, label S) (int, bool) {
+	switch {
+	case idx.byLabel != nil:
+		i, ok := idx.byLabel[string(label)]
+		return i, ok
+	case idx.byLength != nil:
+		if len(label) >= len(idx.byLength) {
+			return 0, false
+		}
+		for _, i := range idx.byLength[len(label)] {
+			if idx.labels[i] == string(label) {
+				return i, true
+			}
+		}
+		return 0, false
+	default:
+		for i, l := range idx.labels {
+			if l == string(label) {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+}
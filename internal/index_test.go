@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestLabelIndexStrategies tests that every strategy finds every label
+func TestLabelIndexStrategies(t *testing.T) {
+	many := make([]string, 50)
+	for i := range many {
+		many[i] = fmt.Sprintf("country_%d", i)
+	}
+
+	tests := []struct {
+		name     string
+		labels   []string
+		strategy string
+	}{
+		{"linear", []string{"a", "b", "c"}, "linear"},
+		{"length dispatch", []string{"pending", "active", "shipped", "cancelled", "lost", "on_hold"}, "length"},
+		{"map for shared lengths", many, "map"},
+		{"map for long labels", []string{"a", "b", "c", "d", string(make([]byte, LengthIndexMaxLen+1))}, "map"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := NewLabelIndex(tt.labels)
+
+			strategy := "linear"
+			if idx.byLength != nil {
+				strategy = "length"
+			} else if idx.byLabel != nil {
+				strategy = "map"
+			}
+			if strategy != tt.strategy {
+				t.Errorf("expected %s strategy, got %s", tt.strategy, strategy)
+			}
+
+			for i, label := range tt.labels {
+				if got, ok := idx.Find(label); !ok || got != i {
+					t.Errorf("Find(%q) = (%d, %v), want (%d, true)", label, got, ok, i)
+				}
+				if got, ok := idx.FindBytes([]byte(label)); !ok || got != i {
+					t.Errorf("FindBytes(%q) = (%d, %v), want (%d, true)", label, got, ok, i)
+				}
+			}
+
+			for _, missing := range []string{"", "missing", "x", string(make([]byte, 200))} {
+				if _, ok := idx.Find(missing); ok {
+					t.Errorf("expected %q not to be found", missing)
+				}
+			}
+		})
+	}
+}
+
+// TestLabelIndexDuplicates tests that the first of duplicate labels wins
+func TestLabelIndexDuplicates(t *testing.T) {
+	labels := []string{"a", "b", "c", "d", "e", "a"}
+	if got, _ := NewLabelIndex(labels).Find("a"); got != 0 {
+		t.Errorf("expected first position 0, got %d", got)
+	}
+
+	many := make([]string, 30)
+	for i := range many {
+		many[i] = fmt.Sprintf("dup_%d", i%10)
+	}
+	if got, _ := NewLabelIndex(many).Find("dup_3"); got != 3 {
+		t.Errorf("expected first position 3, got %d", got)
+	}
+}
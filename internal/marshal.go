@@ -1,85 +1,103 @@
 package internal
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/binary"
 	"encoding/json"
+	"unicode/utf8"
 )
 
 // ToJSON serializes an enum value into JSON.
-func ToJSON[T ~int](labels []string, v T) ([]byte, error) {
-	label := SafeGetLabel(labels, v, InvalidLabel)
-	return json.Marshal(label)
+func ToJSON[T comparable](t *Table[T], v T) ([]byte, error) {
+	data, err := AppendJSON(t, nil, v)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
 // FromJSON deserializes JSON into an enum value.
-func FromJSON[T ~int](labels []string, b []byte) (T, error) {
+// Strings without escape sequences are matched in place, without allocating.
+func FromJSON[T comparable](t *Table[T], b []byte) (T, error) {
+	if label, ok := plainJSONString(b); ok {
+		if val, found := t.LookupBytes(label); found {
+			return val, nil
+		}
+		var zero T
+		return zero, NewInvalidEnumValueError(string(label), t.Labels())
+	}
+
 	var s string
 	if err := json.Unmarshal(b, &s); err != nil {
 		var zero T
 		return zero, err
 	}
 
-	if val, found := StringToIndex[T](labels, s); found {
+	if val, found := t.Lookup(s); found {
 		return val, nil
 	}
 
 	var zero T
-	return zero, NewInvalidEnumValueError(s, labels)
-} // ToYAML serializes an enum value into YAML.
-func ToYAML[T ~int](labels []string, v T) (any, error) {
-	return SafeGetLabel(labels, v, InvalidLabel), nil
+	return zero, NewInvalidEnumValueError(s, t.Labels())
 }
 
-// FromYAML deserializes YAML into an enum value.
-func FromYAML[T ~int](labels []string, unmarshal func(any) error) (T, error) {
-	var s string
-	if err := unmarshal(&s); err != nil {
-		var zero T
-		return zero, err
+// plainJSONString returns the content of a JSON string that holds no escape
+// sequences, and whether b is such a string. Other input must go through
+// encoding/json.
+func plainJSONString(b []byte) ([]byte, bool) {
+	b = bytes.TrimSpace(b)
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return nil, false
 	}
-
-	if val, found := StringToIndex[T](labels, s); found {
-		return val, nil
+	content := b[1 : len(b)-1]
+	for _, c := range content {
+		if c == '\\' || c == '"' || c < 0x20 {
+			return nil, false
+		}
 	}
-
-	var zero T
-	return zero, NewInvalidEnumValueError(s, labels)
+	if !utf8.Valid(content) {
+		return nil, false
+	}
+	return content, true
 }
 
 // ToText serializes an enum value into text (for encoding.TextMarshaler).
-func ToText[T ~int](labels []string, v T) ([]byte, error) {
-	label := SafeGetLabel(labels, v, InvalidLabel)
-	return []byte(label), nil
+func ToText[T comparable](t *Table[T], v T) ([]byte, error) {
+	data, err := AppendText(t, nil, v)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
 // FromText deserializes text into an enum value (for encoding.TextUnmarshaler).
-func FromText[T ~int](labels []string, text []byte) (T, error) {
-	s := string(text)
-	if val, found := StringToIndex[T](labels, s); found {
+func FromText[T comparable](t *Table[T], text []byte) (T, error) {
+	if val, found := t.LookupBytes(text); found {
 		return val, nil
 	}
 
 	var zero T
-	return zero, NewInvalidEnumValueError(s, labels)
+	return zero, NewInvalidEnumValueError(string(text), t.Labels())
 }
 
 // ToBinary serializes an enum value into binary (for encoding.BinaryMarshaler).
-func ToBinary[T ~int](labels []string, v T) ([]byte, error) {
-	label := SafeGetLabel(labels, v, InvalidLabel)
+func ToBinary[T comparable](t *Table[T], v T) ([]byte, error) {
+	label, err := t.MarshalLabel(v)
+	if err != nil {
+		return nil, err
+	}
+	return LabelToBinary(label)
+}
+
+// LabelToBinary encodes a label in the binary format used by ToBinary.
+func LabelToBinary(label string) ([]byte, error) {
 	// Store as length-prefixed string (2 bytes, big-endian) for efficiency
-	labelBytes := []byte(label)
-	if len(labelBytes) > 65535 {
-		return nil, NewLabelTooLongError(len(labelBytes), 65535)
-	}
-	result := make([]byte, 2+len(labelBytes))
-	binary.BigEndian.PutUint16(result[0:2], uint16(len(labelBytes)))
-	copy(result[2:], labelBytes)
-	return result, nil
+	return AppendLabelBinary(make([]byte, 0, 2+len(label)), label)
 }
 
 // FromBinary deserializes binary into an enum value (for encoding.BinaryUnmarshaler).
-func FromBinary[T ~int](labels []string, data []byte) (T, error) {
+func FromBinary[T comparable](t *Table[T], data []byte) (T, error) {
 	var zero T
 
 	if len(data) < 2 {
@@ -92,25 +110,25 @@ func FromBinary[T ~int](labels []string, data []byte) (T, error) {
 		return zero, NewBinaryDataTruncatedError(2+length, len(data))
 	}
 
-	label := string(data[2 : 2+length])
-	if val, found := StringToIndex[T](labels, label); found {
+	label := data[2 : 2+length]
+	if val, found := t.LookupBytes(label); found {
 		return val, nil
 	}
 
-	return zero, NewInvalidEnumValueError(label, labels)
+	return zero, NewInvalidEnumValueError(string(label), t.Labels())
 }
 
 // ToSQLValue serializes an enum value for SQL storage (for driver.Valuer).
-func ToSQLValue[T ~int](labels []string, v T) (driver.Value, error) {
-	if !IsValidIndex(labels, v) {
-		return nil, NewInvalidEnumValueError("", labels)
+func ToSQLValue[T comparable](t *Table[T], v T) (driver.Value, error) {
+	label, ok := t.Label(v)
+	if !ok {
+		return nil, t.RangeError(v)
 	}
-	label := SafeGetLabel(labels, v, InvalidLabel)
 	return label, nil
 }
 
 // FromSQLValue deserializes an SQL value into an enum value (for sql.Scanner).
-func FromSQLValue[T ~int](labels []string, src any) (T, error) {
+func FromSQLValue[T comparable](t *Table[T], src any) (T, error) {
 	var zero T
 
 	if src == nil {
@@ -118,19 +136,18 @@ func FromSQLValue[T ~int](labels []string, src any) (T, error) {
 		return zero, nil
 	}
 
-	var s string
 	switch v := src.(type) {
 	case string:
-		s = v
+		if val, found := t.Lookup(v); found {
+			return val, nil
+		}
+		return zero, NewInvalidEnumValueError(v, t.Labels())
 	case []byte:
-		s = string(v)
+		if val, found := t.LookupBytes(v); found {
+			return val, nil
+		}
+		return zero, NewInvalidEnumValueError(string(v), t.Labels())
 	default:
-		return zero, NewInvalidEnumValueError("non-string SQL value", labels)
+		return zero, NewInvalidEnumValueError("non-string SQL value", t.Labels())
 	}
-
-	if val, found := StringToIndex[T](labels, s); found {
-		return val, nil
-	}
-
-	return zero, NewInvalidEnumValueError(s, labels)
 }
@@ -4,13 +4,18 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"errors"
-	"reflect"
 	"testing"
 )
 
+// newLenientTable returns a table of three labels that writes invalid values
+// as InvalidLabel instead of failing.
+func newLenientTable() *Table[int] {
+	return DenseTable[int]([]string{"first", "second", "third"}).WithLenientMarshal(true)
+}
+
 // TestToJSON tests JSON serialization
 func TestToJSON(t *testing.T) {
-	labels := []string{"first", "second", "third"}
+	table := newLenientTable()
 
 	tests := []struct {
 		name     string
@@ -46,7 +51,7 @@ func TestToJSON(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ToJSON(labels, tt.value)
+			result, err := ToJSON(table, tt.value)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
@@ -110,141 +115,29 @@ func TestFromJSON(t *testing.T) {
 			expectedVal: 0,
 			expectError: true, // Now returns error for invalid values
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := FromJSON[int](labels, []byte(tt.input))
-
-			if tt.expectError {
-				if err == nil {
-					t.Error("expected error but got none")
-				}
-			} else {
-				if err != nil {
-					t.Errorf("expected no error but got: %v", err)
-				}
-				if result != tt.expectedVal {
-					t.Errorf("expected %d, got %d", tt.expectedVal, result)
-				}
-			}
-		})
-	}
-}
-
-// TestToYAML tests YAML serialization
-func TestToYAML(t *testing.T) {
-	labels := []string{"alpha", "beta", "gamma"}
-
-	tests := []struct {
-		name     string
-		value    int
-		expected any
-	}{
-		{
-			name:     "valid first value",
-			value:    0,
-			expected: "alpha",
-		},
-		{
-			name:     "valid middle value",
-			value:    1,
-			expected: "beta",
-		},
-		{
-			name:     "valid last value",
-			value:    2,
-			expected: "gamma",
-		},
-		{
-			name:     "invalid negative value",
-			value:    -1,
-			expected: "Invalid",
-		},
 		{
-			name:     "invalid high value",
-			value:    10,
-			expected: "Invalid",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result, err := ToYAML(labels, tt.value)
-			if err != nil {
-				t.Errorf("unexpected error: %v", err)
-			}
-
-			if result != tt.expected {
-				t.Errorf("expected %v, got %v", tt.expected, result)
-			}
-		})
-	}
-}
-
-// TestFromYAML tests YAML deserialization
-func TestFromYAML(t *testing.T) {
-	labels := []string{"dog", "cat", "bird"}
-
-	tests := []struct {
-		name        string
-		input       any
-		expectedVal int
-		expectError bool
-	}{
-		{
-			name:        "valid first label",
-			input:       "dog",
-			expectedVal: 0,
-			expectError: false,
-		},
-		{
-			name:        "valid middle label",
-			input:       "cat",
+			name:        "escaped label",
+			input:       `"gr\u0065en"`,
 			expectedVal: 1,
 			expectError: false,
 		},
 		{
-			name:        "valid last label",
-			input:       "bird",
+			name:        "surrounding white space",
+			input:       " \"blue\"\n",
 			expectedVal: 2,
 			expectError: false,
 		},
 		{
-			name:        "invalid label",
-			input:       "fish",
-			expectedVal: 0,
-			expectError: true, // Now returns error for invalid values
-		},
-		{
-			name:        "non-string input",
-			input:       123,
+			name:        "unterminated string",
+			input:       `"red`,
 			expectedVal: 0,
 			expectError: true,
 		},
-		{
-			name:        "empty string",
-			input:       "",
-			expectedVal: 0,
-			expectError: true, // Now returns error for invalid values
-		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create unmarshal function that sets the input value
-			unmarshal := func(v any) error {
-				if ptr, ok := v.(*string); ok {
-					if str, ok := tt.input.(string); ok {
-						*ptr = str
-						return nil
-					}
-					return &json.UnsupportedTypeError{Type: reflect.TypeOf(tt.input)}
-				}
-				return &json.UnsupportedTypeError{Type: reflect.TypeOf(v)}
-			}
-
-			result, err := FromYAML[int](labels, unmarshal)
+			result, err := FromJSON(DenseTable[int](labels), []byte(tt.input))
 
 			if tt.expectError {
 				if err == nil {
@@ -268,13 +161,13 @@ func TestJSONRoundTrip(t *testing.T) {
 
 	for i, expectedLabel := range labels {
 		// Serialize
-		jsonBytes, err := ToJSON(labels, i)
+		jsonBytes, err := ToJSON(DenseTable[int](labels), i)
 		if err != nil {
 			t.Fatalf("ToJSON failed for index %d: %v", i, err)
 		}
 
 		// Deserialize
-		result, err := FromJSON[int](labels, jsonBytes)
+		result, err := FromJSON(DenseTable[int](labels), jsonBytes)
 		if err != nil {
 			t.Fatalf("FromJSON failed for index %d: %v", i, err)
 		}
@@ -302,28 +195,20 @@ func TestYAMLRoundTrip(t *testing.T) {
 
 	for i, expectedLabel := range labels {
 		// Serialize
-		yamlValue, err := ToYAML(labels, i)
+		node, err := ToYAMLNode(DenseTable[int](labels), i, 0, false)
 		if err != nil {
-			t.Fatalf("ToYAML failed for index %d: %v", i, err)
+			t.Fatalf("ToYAMLNode failed for index %d: %v", i, err)
 		}
 
 		// Check that YAML value is correct
-		if yamlValue != expectedLabel {
-			t.Errorf("expected YAML value %q, got %v", expectedLabel, yamlValue)
-		}
-
-		// Deserialize using unmarshal function
-		unmarshal := func(v any) error {
-			if ptr, ok := v.(*string); ok {
-				*ptr = expectedLabel
-				return nil
-			}
-			return &json.UnsupportedTypeError{Type: reflect.TypeOf(v)}
+		if node.Value != expectedLabel {
+			t.Errorf("expected YAML value %q, got %v", expectedLabel, node.Value)
 		}
 
-		result, err := FromYAML[int](labels, unmarshal)
+		// Deserialize
+		result, err := FromYAMLNode(DenseTable[int](labels), node)
 		if err != nil {
-			t.Fatalf("FromYAML failed for index %d: %v", i, err)
+			t.Fatalf("FromYAMLNode failed for index %d: %v", i, err)
 		}
 
 		// Check that we got back the original value
@@ -340,12 +225,12 @@ func TestMarshalWithCustomTypes(t *testing.T) {
 	labels := []string{"custom1", "custom2"}
 
 	// Test JSON
-	jsonBytes, err := ToJSON(labels, CustomInt(1))
+	jsonBytes, err := ToJSON(DenseTable[CustomInt](labels), CustomInt(1))
 	if err != nil {
 		t.Errorf("ToJSON failed with custom type: %v", err)
 	}
 
-	result, err := FromJSON[CustomInt](labels, jsonBytes)
+	result, err := FromJSON(DenseTable[CustomInt](labels), jsonBytes)
 	if err != nil {
 		t.Errorf("FromJSON failed with custom type: %v", err)
 	}
@@ -355,13 +240,13 @@ func TestMarshalWithCustomTypes(t *testing.T) {
 	}
 
 	// Test YAML
-	yamlValue, err := ToYAML(labels, CustomInt(0))
+	node, err := ToYAMLNode(DenseTable[CustomInt](labels), CustomInt(0), 0, false)
 	if err != nil {
-		t.Errorf("ToYAML failed with custom type: %v", err)
+		t.Fatalf("ToYAMLNode failed with custom type: %v", err)
 	}
 
-	if yamlValue != "custom1" {
-		t.Errorf("expected 'custom1', got %v", yamlValue)
+	if node.Value != "custom1" {
+		t.Errorf("expected 'custom1', got %v", node.Value)
 	}
 }
 
@@ -370,23 +255,16 @@ func TestMarshalEmptyLabels(t *testing.T) {
 	labels := []string{}
 
 	// Test JSON
-	jsonBytes, err := ToJSON(labels, 0)
-	if err != nil {
-		t.Errorf("ToJSON failed with empty labels: %v", err)
-	}
-
-	if string(jsonBytes) != `"Invalid"` {
-		t.Errorf("expected '\"Invalid\"', got %s", string(jsonBytes))
+	var rangeErr *ErrValueOutOfRange
+	if _, err := ToJSON(DenseTable[int](labels), 0); !errors.As(err, &rangeErr) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
+	} else if rangeErr.Min != "" || rangeErr.Max != "" {
+		t.Errorf("expected empty bounds, got [%s, %s]", rangeErr.Min, rangeErr.Max)
 	}
 
 	// Test YAML
-	yamlValue, err := ToYAML(labels, 0)
-	if err != nil {
-		t.Errorf("ToYAML failed with empty labels: %v", err)
-	}
-
-	if yamlValue != "Invalid" {
-		t.Errorf("expected 'Invalid', got %v", yamlValue)
+	if _, err := ToYAMLNode(DenseTable[int](labels), 0, 0, false); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
 	}
 }
 
@@ -399,12 +277,12 @@ func TestMarshalLargeEnum(t *testing.T) {
 	}
 
 	// Test JSON with large enum
-	jsonBytes, err := ToJSON(labels, 25)
+	jsonBytes, err := ToJSON(DenseTable[int](labels), 25)
 	if err != nil {
 		t.Errorf("ToJSON failed with large enum: %v", err)
 	}
 
-	result, err := FromJSON[int](labels, jsonBytes)
+	result, err := FromJSON(DenseTable[int](labels), jsonBytes)
 	if err != nil {
 		t.Errorf("FromJSON failed with large enum: %v", err)
 	}
@@ -414,20 +292,20 @@ func TestMarshalLargeEnum(t *testing.T) {
 	}
 
 	// Test YAML with large enum
-	yamlValue, err := ToYAML(labels, 30)
+	node, err := ToYAMLNode(DenseTable[int](labels), 30, 0, false)
 	if err != nil {
-		t.Errorf("ToYAML failed with large enum: %v", err)
+		t.Fatalf("ToYAMLNode failed with large enum: %v", err)
 	}
 
 	expectedLabel := labels[30]
-	if yamlValue != expectedLabel {
-		t.Errorf("expected %q, got %v", expectedLabel, yamlValue)
+	if node.Value != expectedLabel {
+		t.Errorf("expected %q, got %v", expectedLabel, node.Value)
 	}
 }
 
 // TestToText tests text marshalling
 func TestToText(t *testing.T) {
-	labels := []string{"first", "second", "third"}
+	table := newLenientTable()
 
 	tests := []struct {
 		name     string
@@ -453,7 +331,7 @@ func TestToText(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ToText(labels, tt.value)
+			result, err := ToText(table, tt.value)
 			if err != nil {
 				t.Errorf("ToText failed: %v", err)
 				return
@@ -498,7 +376,7 @@ func TestFromText(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := FromText[int](labels, []byte(tt.text))
+			result, err := FromText(DenseTable[int](labels), []byte(tt.text))
 
 			if tt.wantError {
 				if err == nil {
@@ -521,7 +399,7 @@ func TestFromText(t *testing.T) {
 
 // TestToBinary tests binary marshalling
 func TestToBinary(t *testing.T) {
-	labels := []string{"first", "second", "third"}
+	table := newLenientTable()
 
 	tests := []struct {
 		name  string
@@ -547,7 +425,7 @@ func TestToBinary(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ToBinary(labels, tt.value)
+			result, err := ToBinary(table, tt.value)
 			if err != nil {
 				t.Errorf("ToBinary failed: %v", err)
 				return
@@ -618,7 +496,7 @@ func TestFromBinary(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := FromBinary[int](labels, tt.data)
+			result, err := FromBinary(DenseTable[int](labels), tt.data)
 
 			if tt.wantErr {
 				if err == nil {
@@ -646,13 +524,13 @@ func TestTextBinaryRoundTrip(t *testing.T) {
 	for i, label := range labels {
 		t.Run(label, func(t *testing.T) {
 			// Test Text round trip
-			textBytes, err := ToText(labels, i)
+			textBytes, err := ToText(DenseTable[int](labels), i)
 			if err != nil {
 				t.Errorf("ToText failed: %v", err)
 				return
 			}
 
-			textResult, err := FromText[int](labels, textBytes)
+			textResult, err := FromText(DenseTable[int](labels), textBytes)
 			if err != nil {
 				t.Errorf("FromText failed: %v", err)
 				return
@@ -663,13 +541,13 @@ func TestTextBinaryRoundTrip(t *testing.T) {
 			}
 
 			// Test Binary round trip
-			binaryBytes, err := ToBinary(labels, i)
+			binaryBytes, err := ToBinary(DenseTable[int](labels), i)
 			if err != nil {
 				t.Errorf("ToBinary failed: %v", err)
 				return
 			}
 
-			binaryResult, err := FromBinary[int](labels, binaryBytes)
+			binaryResult, err := FromBinary(DenseTable[int](labels), binaryBytes)
 			if err != nil {
 				t.Errorf("FromBinary failed: %v", err)
 				return
@@ -688,7 +566,7 @@ func TestToBinaryLabelTooLong(t *testing.T) {
 	longLabel := string(make([]byte, 65536))
 	labels := []string{longLabel}
 
-	_, err := ToBinary[int](labels, 0)
+	_, err := ToBinary(DenseTable[int](labels), 0)
 	if err == nil {
 		t.Error("expected error for label too long, got nil")
 		return
@@ -747,7 +625,7 @@ func TestToSQLValue(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := ToSQLValue[int](labels, tt.value)
+			result, err := ToSQLValue(DenseTable[int](labels), tt.value)
 
 			if tt.hasError {
 				if err == nil {
@@ -822,7 +700,7 @@ func TestFromSQLValue(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := FromSQLValue[int](labels, tt.src)
+			result, err := FromSQLValue(DenseTable[int](labels), tt.src)
 
 			if tt.hasError {
 				if err == nil {
@@ -851,13 +729,13 @@ func TestSQLRoundTrip(t *testing.T) {
 	for _, value := range testValues {
 		t.Run("round trip", func(t *testing.T) {
 			// Convert to SQL value
-			sqlValue, err := ToSQLValue[int](labels, value)
+			sqlValue, err := ToSQLValue(DenseTable[int](labels), value)
 			if err != nil {
 				t.Fatalf("ToSQLValue failed: %v", err)
 			}
 
 			// Convert back from SQL value
-			result, err := FromSQLValue[int](labels, sqlValue)
+			result, err := FromSQLValue(DenseTable[int](labels), sqlValue)
 			if err != nil {
 				t.Fatalf("FromSQLValue failed: %v", err)
 			}
@@ -869,3 +747,53 @@ func TestSQLRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+// TestMarshalExplicitValues tests marshalling with non-contiguous values
+func TestMarshalExplicitValues(t *testing.T) {
+	table := NewTable([]string{"active", "suspended", "deleted"}, []int{10, 20, 99})
+
+	jsonBytes, err := ToJSON(table, 20)
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if string(jsonBytes) != `"suspended"` {
+		t.Errorf("expected %q, got %s", `"suspended"`, jsonBytes)
+	}
+
+	result, err := FromJSON(table, []byte(`"deleted"`))
+	if err != nil {
+		t.Fatalf("FromJSON failed: %v", err)
+	}
+	if result != 99 {
+		t.Errorf("expected 99, got %d", result)
+	}
+
+	// Indices are not valid values for explicit tables
+	if _, err := ToText(table, 1); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
+	}
+
+	if _, err := ToSQLValue(table, 1); err == nil {
+		t.Error("expected error for index that is not a value")
+	}
+
+	binaryData, err := ToBinary(table, 99)
+	if err != nil {
+		t.Fatalf("ToBinary failed: %v", err)
+	}
+	result, err = FromBinary(table, binaryData)
+	if err != nil {
+		t.Fatalf("FromBinary failed: %v", err)
+	}
+	if result != 99 {
+		t.Errorf("expected 99, got %d", result)
+	}
+
+	result, err = FromSQLValue(table, []byte("active"))
+	if err != nil {
+		t.Fatalf("FromSQLValue failed: %v", err)
+	}
+	if result != 10 {
+		t.Errorf("expected 10, got %d", result)
+	}
+}
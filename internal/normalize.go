@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ParseMode controls how input labels are matched against enum labels.
+// Modes can be combined with bitwise OR.
+type ParseMode uint8
+
+const (
+	// ParseExact matches labels byte for byte.
+	ParseExact ParseMode = 0
+
+	// ParseCaseInsensitive matches labels using Unicode simple case folding.
+	ParseCaseInsensitive ParseMode = 1 << iota
+
+	// ParseTrimSpace ignores leading and trailing white space in the input.
+	ParseTrimSpace
+
+	// ParseIgnoreSeparators treats snake_case, kebab-case and camelCase
+	// spellings as equivalent by ignoring '_', '-' and case.
+	ParseIgnoreSeparators
+)
+
+// Normalize returns the lookup key of s under the given mode.
+// Two strings match under a mode when their keys are equal.
+func Normalize(s string, mode ParseMode) string {
+	if mode&ParseTrimSpace != 0 {
+		s = strings.TrimSpace(s)
+	}
+	if mode&ParseIgnoreSeparators != 0 {
+		s = strings.Map(func(r rune) rune {
+			if r == '_' || r == '-' {
+				return -1
+			}
+			return r
+		}, s)
+		mode |= ParseCaseInsensitive
+	}
+	if mode&ParseCaseInsensitive != 0 {
+		s = strings.Map(foldRune, s)
+	}
+	return s
+}
+
+// foldRune maps a rune to the smallest rune of its case folding orbit, so
+// that runes equal under strings.EqualFold share the same representative.
+func foldRune(r rune) rune {
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < smallest {
+			smallest = f
+		}
+	}
+	return smallest
+}
@@ -0,0 +1,36 @@
+package internal
+
+import "testing"
+
+// TestNormalize tests lookup keys under each parse mode
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		mode     ParseMode
+		expected bool
+	}{
+		{name: "exact same", a: "active", b: "active", mode: ParseExact, expected: true},
+		{name: "exact case differs", a: "Active", b: "active", mode: ParseExact, expected: false},
+		{name: "case insensitive", a: "ACTIVE", b: "active", mode: ParseCaseInsensitive, expected: true},
+		{name: "unicode folding", a: "ÉTÉ", b: "été", mode: ParseCaseInsensitive, expected: true},
+		{name: "kelvin sign folding", a: "K", b: "k", mode: ParseCaseInsensitive, expected: true},
+		{name: "case insensitive keeps spaces", a: " active", b: "active", mode: ParseCaseInsensitive, expected: false},
+		{name: "trim space", a: " active\t", b: "active", mode: ParseTrimSpace, expected: true},
+		{name: "trim space keeps case", a: " Active ", b: "active", mode: ParseTrimSpace, expected: false},
+		{name: "combined", a: "  ACTIVE ", b: "active", mode: ParseTrimSpace | ParseCaseInsensitive, expected: true},
+		{name: "snake vs kebab", a: "in-progress", b: "in_progress", mode: ParseIgnoreSeparators, expected: true},
+		{name: "snake vs camel", a: "InProgress", b: "in_progress", mode: ParseIgnoreSeparators, expected: true},
+		{name: "separators keep letters", a: "in_process", b: "in_progress", mode: ParseIgnoreSeparators, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Normalize(tt.a, tt.mode) == Normalize(tt.b, tt.mode)
+			if result != tt.expected {
+				t.Errorf("expected match %v for %q and %q, got %v", tt.expected, tt.a, tt.b, result)
+			}
+		})
+	}
+}
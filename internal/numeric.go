@@ -0,0 +1,157 @@
+package internal
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// Representation selects how enum values are written to and read from JSON and SQL.
+type Representation uint8
+
+const (
+	// RepresentLabel reads and writes labels.
+	RepresentLabel Representation = iota
+
+	// RepresentNumber reads and writes numeric values.
+	RepresentNumber
+
+	// RepresentLabelOrNumber reads labels or numeric values and writes labels.
+	RepresentLabelOrNumber
+)
+
+// ParseInteger parses a base 10 integer into T.
+// It reports false if s is not an integer or does not fit in T.
+func ParseInteger[T Integer](s string) (T, bool) {
+	var zero T
+	if zero-1 < zero {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || int64(T(n)) != n {
+			return zero, false
+		}
+		return T(n), true
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || uint64(T(n)) != n {
+		return zero, false
+	}
+	return T(n), true
+}
+
+// LookupNumber parses a numeric value and checks that it belongs to the table.
+func LookupNumber[T Integer](t *Table[T], s string) (T, error) {
+	if v, ok := ParseInteger[T](s); ok && t.Contains(v) {
+		return v, nil
+	}
+	var zero T
+	return zero, NewInvalidEnumValueError(s, t.Labels())
+}
+
+// lookupInt64 checks that an SQL integer fits in T and belongs to the table.
+func lookupInt64[T Integer](t *Table[T], n int64) (T, error) {
+	if v := T(n); int64(v) == n && (n >= 0 || v < 0) && t.Contains(v) {
+		return v, nil
+	}
+	var zero T
+	return zero, NewInvalidEnumValueError(strconv.FormatInt(n, 10), t.Labels())
+}
+
+// ToJSONAs serializes an enum value into JSON using the given representation.
+func ToJSONAs[T Integer](t *Table[T], v T, r Representation) ([]byte, error) {
+	if r != RepresentNumber {
+		return ToJSON(t, v)
+	}
+	data, err := AppendJSONAs(t, nil, v, r)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// FromJSONAs deserializes JSON into an enum value using the given representation.
+// Numbers are only accepted by RepresentNumber and RepresentLabelOrNumber,
+// and labels only by RepresentLabel and RepresentLabelOrNumber.
+func FromJSONAs[T Integer](t *Table[T], b []byte, r Representation) (T, error) {
+	trimmed := bytes.TrimSpace(b)
+	isString := len(trimmed) > 0 && trimmed[0] == '"'
+	if r == RepresentLabel || (r == RepresentLabelOrNumber && isString) {
+		return FromJSON(t, b)
+	}
+
+	var zero T
+	if isString {
+		// RepresentNumber rejects labels and quoted numbers alike.
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return zero, err
+		}
+		return zero, NewInvalidEnumValueError(s, t.Labels())
+	}
+	if v, ok := ParseInteger[T](string(trimmed)); ok && t.Contains(v) {
+		return v, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return zero, err
+	}
+	return LookupNumber(t, string(n))
+}
+
+// ToSQLValueAs serializes an enum value for SQL storage using the given representation.
+// Numbers are stored as int64, as required by driver.Value.
+func ToSQLValueAs[T Integer](t *Table[T], v T, r Representation) (driver.Value, error) {
+	if r != RepresentNumber {
+		return ToSQLValue(t, v)
+	}
+	n, err := ToInt64(t, v)
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// ToInt64 converts a member of the table to int64. Members of unsigned
+// enums above math.MaxInt64 return an *ErrValueOutOfRange.
+func ToInt64[T Integer](t *Table[T], v T) (int64, error) {
+	if !t.Contains(v) {
+		return 0, t.RangeError(v)
+	}
+	if v > 0 && uint64(v) > math.MaxInt64 {
+		return 0, NewValueOutOfRangeError(formatInteger(v),
+			strconv.FormatInt(math.MinInt64, 10), strconv.FormatInt(math.MaxInt64, 10))
+	}
+	return int64(v), nil
+}
+
+// FromSQLValueAs deserializes an SQL value into an enum value using the given representation.
+// With RepresentNumber, textual columns holding digits are accepted as well,
+// since some drivers return integers as []byte.
+func FromSQLValueAs[T Integer](t *Table[T], src any, r Representation) (T, error) {
+	var zero T
+
+	switch r {
+	case RepresentNumber:
+		switch v := src.(type) {
+		case nil:
+			// SQL NULL maps to zero value
+			return zero, nil
+		case int64:
+			return lookupInt64(t, v)
+		case string:
+			return LookupNumber(t, v)
+		case []byte:
+			return LookupNumber(t, string(v))
+		default:
+			return zero, NewInvalidEnumValueError("non-integer SQL value", t.Labels())
+		}
+	case RepresentLabelOrNumber:
+		if v, ok := src.(int64); ok {
+			return lookupInt64(t, v)
+		}
+		return FromSQLValue(t, src)
+	default:
+		return FromSQLValue(t, src)
+	}
+}
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func newCodeTable() *Table[int] {
+	return NewTable([]string{"active", "suspended", "deleted"}, []int{10, 20, 99})
+}
+
+// TestParseInteger tests integer parsing with range checks
+func TestParseInteger(t *testing.T) {
+	if v, ok := ParseInteger[int8]("-128"); !ok || v != -128 {
+		t.Errorf("expected (-128, true), got (%d, %v)", v, ok)
+	}
+	if _, ok := ParseInteger[int8]("128"); ok {
+		t.Error("expected 128 to overflow int8")
+	}
+	if v, ok := ParseInteger[uint64]("18446744073709551615"); !ok || v != ^uint64(0) {
+		t.Errorf("expected max uint64, got (%d, %v)", v, ok)
+	}
+	if _, ok := ParseInteger[uint16]("-1"); ok {
+		t.Error("expected -1 to be rejected for uint16")
+	}
+	if _, ok := ParseInteger[int]("1.5"); ok {
+		t.Error("expected 1.5 to be rejected")
+	}
+}
+
+// TestToJSONAs tests JSON serialization for each representation
+func TestToJSONAs(t *testing.T) {
+	table := newCodeTable()
+
+	tests := []struct {
+		name        string
+		repr        Representation
+		value       int
+		expected    string
+		expectError bool
+	}{
+		{name: "label", repr: RepresentLabel, value: 20, expected: `"suspended"`},
+		{name: "number", repr: RepresentNumber, value: 20, expected: `20`},
+		{name: "label or number emits label", repr: RepresentLabelOrNumber, value: 99, expected: `"deleted"`},
+		{name: "number out of range", repr: RepresentNumber, value: 3, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ToJSONAs(table, tt.value, tt.repr)
+			if tt.expectError {
+				if !errors.Is(err, &ErrValueOutOfRange{}) {
+					t.Errorf("expected ErrValueOutOfRange, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestFromJSONAs tests JSON deserialization for each representation
+func TestFromJSONAs(t *testing.T) {
+	table := newCodeTable()
+
+	tests := []struct {
+		name        string
+		repr        Representation
+		input       string
+		expected    int
+		expectError bool
+	}{
+		{name: "label accepts label", repr: RepresentLabel, input: `"deleted"`, expected: 99},
+		{name: "label rejects number", repr: RepresentLabel, input: `99`, expectError: true},
+		{name: "number accepts number", repr: RepresentNumber, input: `20`, expected: 20},
+		{name: "number rejects label", repr: RepresentNumber, input: `"suspended"`, expectError: true},
+		{name: "number rejects quoted number", repr: RepresentNumber, input: `"20"`, expectError: true},
+		{name: "number rejects unknown code", repr: RepresentNumber, input: `21`, expectError: true},
+		{name: "number rejects fraction", repr: RepresentNumber, input: `20.5`, expectError: true},
+		{name: "both accepts label", repr: RepresentLabelOrNumber, input: `"active"`, expected: 10},
+		{name: "both accepts number", repr: RepresentLabelOrNumber, input: ` 99`, expected: 99},
+		{name: "both rejects unknown code", repr: RepresentLabelOrNumber, input: `1`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FromJSONAs(table, []byte(tt.input), tt.repr)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				} else if tt.repr == RepresentNumber && !errors.Is(err, &ErrInvalidEnumValue{}) {
+					t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestSQLValueAs tests SQL conversion for each representation
+func TestSQLValueAs(t *testing.T) {
+	table := newCodeTable()
+
+	value, err := ToSQLValueAs(table, 99, RepresentNumber)
+	if err != nil {
+		t.Fatalf("ToSQLValueAs failed: %v", err)
+	}
+	if value != int64(99) {
+		t.Errorf("expected int64(99), got %#v", value)
+	}
+
+	if _, err := ToSQLValueAs(table, 5, RepresentNumber); err == nil {
+		t.Error("expected error for value that is not a member")
+	}
+
+	big := NewTable([]string{"big"}, []uint64{math.MaxUint64})
+	if _, err := ToSQLValueAs(big, math.MaxUint64, RepresentNumber); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange for a member above MaxInt64, got %v", err)
+	}
+
+	value, err = ToSQLValueAs(table, 99, RepresentLabelOrNumber)
+	if err != nil || value != "deleted" {
+		t.Errorf("expected label, got %v (err %v)", value, err)
+	}
+
+	tests := []struct {
+		name        string
+		repr        Representation
+		src         any
+		expected    int
+		expectError bool
+	}{
+		{name: "number int64", repr: RepresentNumber, src: int64(20), expected: 20},
+		{name: "number bytes", repr: RepresentNumber, src: []byte("99"), expected: 99},
+		{name: "number null", repr: RepresentNumber, src: nil, expected: 0},
+		{name: "number rejects label", repr: RepresentNumber, src: "active", expectError: true},
+		{name: "number rejects unknown code", repr: RepresentNumber, src: int64(11), expectError: true},
+		{name: "number rejects float", repr: RepresentNumber, src: 10.0, expectError: true},
+		{name: "label rejects int64", repr: RepresentLabel, src: int64(10), expectError: true},
+		{name: "both int64", repr: RepresentLabelOrNumber, src: int64(10), expected: 10},
+		{name: "both label", repr: RepresentLabelOrNumber, src: "suspended", expected: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := FromSQLValueAs(table, tt.src, tt.repr)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
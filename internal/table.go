@@ -0,0 +1,278 @@
+package internal
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// Table pairs enum labels with their values.
+// The label at position i belongs to the value at position i, so values
+// do not have to be contiguous or start at zero.
+type Table[T comparable] struct {
+	labels     []string
+	values     []T
+	index      map[T]int
+	exact      *LabelIndex
+	mode       ParseMode
+	normalized map[string]int
+	aliases    []Alias
+	aliasKeys  map[string]int
+	onAlias    func(alias, label string)
+	// lenient makes MarshalLabel return InvalidLabel for non-members instead of an error.
+	lenient bool
+}
+
+// Alias is an alternative spelling that resolves to the label at Index.
+type Alias struct {
+	Label      string
+	Index      int
+	Deprecated bool
+}
+
+// NewTable creates a table from parallel label and value slices.
+// When a value appears more than once, the first label wins for value-to-label lookups.
+// Labels are indexed once with a LabelIndex suited to their number and shape.
+func NewTable[T comparable](labels []string, values []T) *Table[T] {
+	index := make(map[T]int, len(values))
+	for i, v := range values {
+		if _, exists := index[v]; !exists {
+			index[v] = i
+		}
+	}
+	return &Table[T]{
+		labels: labels,
+		values: values,
+		index:  index,
+		exact:  NewLabelIndex(labels),
+	}
+}
+
+// DenseTable creates a table whose values are the label indices 0..n-1.
+func DenseTable[T Integer](labels []string) *Table[T] {
+	return NewTable(labels, NewCacheBuilder[T](labels).BuildAllValues())
+}
+
+// WithMode returns a copy of the table that matches input labels under mode.
+// When several labels share the same normalized form, the first one wins.
+func (t *Table[T]) WithMode(mode ParseMode) *Table[T] {
+	cp := *t
+	cp.mode = mode
+	cp.normalized = nil
+	if mode != ParseExact {
+		cp.normalized = make(map[string]int, len(t.labels))
+		for i, label := range t.labels {
+			key := Normalize(label, mode)
+			if _, exists := cp.normalized[key]; !exists {
+				cp.normalized[key] = i
+			}
+		}
+	}
+	cp.buildAliasKeys()
+	return &cp
+}
+
+// WithAliases returns a copy of the table that also resolves the given aliases.
+// Aliases are matched under the parse mode of the table, after the labels.
+func (t *Table[T]) WithAliases(aliases ...Alias) *Table[T] {
+	cp := *t
+	cp.aliases = append(append([]Alias(nil), t.aliases...), aliases...)
+	cp.buildAliasKeys()
+	return &cp
+}
+
+// WithAliasHook returns a copy of the table that calls hook with the alias and
+// its canonical label whenever a deprecated alias is resolved by Lookup.
+func (t *Table[T]) WithAliasHook(hook func(alias, label string)) *Table[T] {
+	cp := *t
+	cp.onAlias = hook
+	return &cp
+}
+
+// WithLenientMarshal returns a copy of the table whose MarshalLabel returns
+// InvalidLabel for values that do not belong to it, instead of an error.
+func (t *Table[T]) WithLenientMarshal(lenient bool) *Table[T] {
+	cp := *t
+	cp.lenient = lenient
+	return &cp
+}
+
+// LenientMarshal reports whether MarshalLabel accepts values that do not belong to the table.
+func (t *Table[T]) LenientMarshal() bool {
+	return t.lenient
+}
+
+// Aliases returns the aliases of the table.
+// WARNING: Do not modify the returned slice as it shares memory with the table.
+func (t *Table[T]) Aliases() []Alias {
+	return t.aliases
+}
+
+// buildAliasKeys indexes the aliases under the parse mode of the table.
+// When several aliases share the same normalized form, the first one wins.
+func (t *Table[T]) buildAliasKeys() {
+	t.aliasKeys = nil
+	if len(t.aliases) == 0 {
+		return
+	}
+	t.aliasKeys = make(map[string]int, len(t.aliases))
+	for i, alias := range t.aliases {
+		key := Normalize(alias.Label, t.mode)
+		if _, exists := t.aliasKeys[key]; !exists {
+			t.aliasKeys[key] = i
+		}
+	}
+}
+
+// Mode returns the parse mode of the table.
+func (t *Table[T]) Mode() ParseMode {
+	return t.mode
+}
+
+// Labels returns the labels of the table.
+// WARNING: Do not modify the returned slice as it shares memory with the table.
+func (t *Table[T]) Labels() []string {
+	return t.labels
+}
+
+// Values returns the values of the table in label order.
+// WARNING: Do not modify the returned slice as it shares memory with the table.
+func (t *Table[T]) Values() []T {
+	return t.values
+}
+
+// Label returns the label of a value and whether the value belongs to the table.
+func (t *Table[T]) Label(v T) (string, bool) {
+	if i, ok := t.index[v]; ok {
+		return t.labels[i], true
+	}
+	return "", false
+}
+
+// SafeLabel returns the label of a value, or a default value if it does not belong to the table.
+func (t *Table[T]) SafeLabel(v T, defaultLabel string) string {
+	if label, ok := t.Label(v); ok {
+		return label
+	}
+	return defaultLabel
+}
+
+// MarshalLabel returns the label to write for a value. A value that does not
+// belong to the table returns an *ErrValueOutOfRange, or InvalidLabel if the
+// table is lenient.
+func (t *Table[T]) MarshalLabel(v T) (string, error) {
+	if label, ok := t.Label(v); ok {
+		return label, nil
+	}
+	if t.lenient {
+		return InvalidLabel, nil
+	}
+	return "", t.RangeError(v)
+}
+
+// RangeError returns an *ErrValueOutOfRange for a value that does not belong
+// to the table, with the smallest and largest values of the table as bounds.
+func (t *Table[T]) RangeError(v T) *ErrValueOutOfRange {
+	if len(t.values) == 0 {
+		return NewValueOutOfRangeError(formatValue(v), "", "")
+	}
+	lo, hi := t.values[0], t.values[0]
+	for _, value := range t.values[1:] {
+		if lessValue(value, lo) {
+			lo = value
+		}
+		if lessValue(hi, value) {
+			hi = value
+		}
+	}
+	return NewValueOutOfRangeError(formatValue(v), formatValue(lo), formatValue(hi))
+}
+
+// Lookup returns the value of a label and whether the label belongs to the table.
+// The label is matched according to the parse mode of the table, then
+// against the aliases.
+func (t *Table[T]) Lookup(label string) (T, bool) {
+	return lookupLabel(t, label)
+}
+
+// LookupBytes is like Lookup for a label held in a byte slice.
+// With ParseExact, it does not allocate unless a deprecated alias is reported.
+func (t *Table[T]) LookupBytes(label []byte) (T, bool) {
+	return lookupLabel(t, label)
+}
+
+// lookupLabel implements Lookup and LookupBytes.
+func lookupLabel[T comparable, S string | []byte](t *Table[T], label S) (T, bool) {
+	if i, ok := labelIndex(t, label); ok {
+		return t.values[i], true
+	}
+	if len(t.aliasKeys) > 0 {
+		var i int
+		var ok bool
+		if t.mode == ParseExact {
+			i, ok = t.aliasKeys[string(label)]
+		} else {
+			i, ok = t.aliasKeys[Normalize(string(label), t.mode)]
+		}
+		if ok {
+			alias := t.aliases[i]
+			if alias.Deprecated && t.onAlias != nil {
+				t.onAlias(string(label), t.labels[alias.Index])
+			}
+			return t.values[alias.Index], true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// labelIndex returns the index of a label according to the parse mode.
+func labelIndex[T comparable, S string | []byte](t *Table[T], label S) (int, bool) {
+	if t.mode != ParseExact {
+		i, ok := t.normalized[Normalize(string(label), t.mode)]
+		return i, ok
+	}
+	return indexLookup(t.exact, label)
+}
+
+// Index returns the position of a value and whether the value belongs to the table.
+func (t *Table[T]) Index(v T) (int, bool) {
+	i, ok := t.index[v]
+	return i, ok
+}
+
+// Contains reports whether a value belongs to the table.
+func (t *Table[T]) Contains(v T) bool {
+	_, ok := t.index[v]
+	return ok
+}
+
+// formatValue formats an integer or string value without calling its String
+// method, which usually maps it back to a label.
+func formatValue[T comparable](v T) string {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.CanInt():
+		return strconv.FormatInt(rv.Int(), 10)
+	case rv.CanUint():
+		return strconv.FormatUint(rv.Uint(), 10)
+	case rv.Kind() == reflect.String:
+		return rv.String()
+	default:
+		return ""
+	}
+}
+
+// lessValue reports whether a sorts before b, for integer and string values.
+func lessValue[T comparable](a, b T) bool {
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case ra.CanInt():
+		return ra.Int() < rb.Int()
+	case ra.CanUint():
+		return ra.Uint() < rb.Uint()
+	case ra.Kind() == reflect.String:
+		return ra.String() < rb.String()
+	default:
+		return false
+	}
+}
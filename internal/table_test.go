@@ -0,0 +1,274 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestNewTable tests table creation with explicit values
+func TestNewTable(t *testing.T) {
+	labels := []string{"active", "suspended", "deleted"}
+	values := []int{10, 20, 99}
+	table := NewTable(labels, values)
+
+	if !reflect.DeepEqual(table.Labels(), labels) {
+		t.Errorf("expected labels %v, got %v", labels, table.Labels())
+	}
+
+	if !reflect.DeepEqual(table.Values(), values) {
+		t.Errorf("expected values %v, got %v", values, table.Values())
+	}
+}
+
+// TestDenseTable tests that dense tables use label indices as values
+func TestDenseTable(t *testing.T) {
+	table := DenseTable[int]([]string{"a", "b", "c"})
+	expected := []int{0, 1, 2}
+
+	if !reflect.DeepEqual(table.Values(), expected) {
+		t.Errorf("expected values %v, got %v", expected, table.Values())
+	}
+}
+
+// TestTableLabel tests value-to-label lookup
+func TestTableLabel(t *testing.T) {
+	table := NewTable([]string{"active", "suspended", "deleted"}, []int{10, 20, 99})
+
+	tests := []struct {
+		name          string
+		value         int
+		expectedLabel string
+		expectedOk    bool
+	}{
+		{
+			name:          "first value",
+			value:         10,
+			expectedLabel: "active",
+			expectedOk:    true,
+		},
+		{
+			name:          "last value",
+			value:         99,
+			expectedLabel: "deleted",
+			expectedOk:    true,
+		},
+		{
+			name:          "index of a member is not a value",
+			value:         1,
+			expectedLabel: "",
+			expectedOk:    false,
+		},
+		{
+			name:          "gap between values",
+			value:         50,
+			expectedLabel: "",
+			expectedOk:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, ok := table.Label(tt.value)
+			if ok != tt.expectedOk {
+				t.Errorf("expected ok %v, got %v", tt.expectedOk, ok)
+			}
+			if label != tt.expectedLabel {
+				t.Errorf("expected label %q, got %q", tt.expectedLabel, label)
+			}
+			if table.Contains(tt.value) != tt.expectedOk {
+				t.Errorf("expected Contains %v for %d", tt.expectedOk, tt.value)
+			}
+		})
+	}
+
+	if got := table.SafeLabel(50, InvalidLabel); got != InvalidLabel {
+		t.Errorf("expected %q, got %q", InvalidLabel, got)
+	}
+}
+
+// TestTableLookup tests label-to-value lookup for small and large tables
+func TestTableLookup(t *testing.T) {
+	small := NewTable([]string{"low", "high"}, []int{-5, 5})
+	if val, ok := small.Lookup("high"); !ok || val != 5 {
+		t.Errorf("expected (5, true), got (%d, %v)", val, ok)
+	}
+	if _, ok := small.Lookup("medium"); ok {
+		t.Error("expected lookup of unknown label to fail")
+	}
+
+	labels := generateLabels(LinearIndexMax + 5)
+	values := make([]int, len(labels))
+	for i := range values {
+		values[i] = (i + 1) * 100
+	}
+	large := NewTable(labels, values)
+	if val, ok := large.Lookup(labels[7]); !ok || val != 800 {
+		t.Errorf("expected (800, true), got (%d, %v)", val, ok)
+	}
+}
+
+// TestTableDuplicateValues tests that the first label wins for repeated values
+func TestTableDuplicateValues(t *testing.T) {
+	table := NewTable([]string{"canceled", "cancelled"}, []int{3, 3})
+
+	if label, _ := table.Label(3); label != "canceled" {
+		t.Errorf("expected first label %q, got %q", "canceled", label)
+	}
+	if val, ok := table.Lookup("cancelled"); !ok || val != 3 {
+		t.Errorf("expected (3, true), got (%d, %v)", val, ok)
+	}
+}
+
+// TestTableWithMode tests label lookups under a parse mode
+func TestTableWithMode(t *testing.T) {
+	exact := DenseTable[int]([]string{"pending", "in_progress", "done"})
+	relaxed := exact.WithMode(ParseTrimSpace | ParseIgnoreSeparators)
+
+	if relaxed.Mode() != ParseTrimSpace|ParseIgnoreSeparators {
+		t.Errorf("unexpected mode %d", relaxed.Mode())
+	}
+	if exact.Mode() != ParseExact {
+		t.Error("WithMode should not modify the original table")
+	}
+
+	if val, ok := relaxed.Lookup(" InProgress "); !ok || val != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", val, ok)
+	}
+	if _, ok := exact.Lookup("InProgress"); ok {
+		t.Error("exact table should not match a different spelling")
+	}
+	if _, ok := relaxed.Lookup("cancelled"); ok {
+		t.Error("expected lookup of unknown label to fail")
+	}
+
+	// The canonical label is still returned for values
+	if label, _ := relaxed.Label(1); label != "in_progress" {
+		t.Errorf("expected canonical label %q, got %q", "in_progress", label)
+	}
+
+	// Back to exact mode
+	if _, ok := relaxed.WithMode(ParseExact).Lookup("DONE"); ok {
+		t.Error("exact mode should be case sensitive")
+	}
+}
+
+// TestTableWithAliases tests alias resolution and the deprecation hook
+func TestTableWithAliases(t *testing.T) {
+	var reported []string
+	table := DenseTable[int]([]string{"pending", "cancelled"}).
+		WithAliases(
+			Alias{Label: "canceled", Index: 1, Deprecated: true},
+			Alias{Label: "waiting", Index: 0},
+		).
+		WithAliasHook(func(alias, label string) {
+			reported = append(reported, alias+"->"+label)
+		})
+
+	if val, ok := table.Lookup("canceled"); !ok || val != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", val, ok)
+	}
+	if val, ok := table.Lookup("waiting"); !ok || val != 0 {
+		t.Errorf("expected (0, true), got (%d, %v)", val, ok)
+	}
+	if val, ok := table.Lookup("cancelled"); !ok || val != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", val, ok)
+	}
+
+	if len(reported) != 1 || reported[0] != "canceled->cancelled" {
+		t.Errorf("expected one deprecated alias report, got %v", reported)
+	}
+
+	// Aliases follow the parse mode
+	relaxed := table.WithMode(ParseCaseInsensitive)
+	if val, ok := relaxed.Lookup("CANCELED"); !ok || val != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", val, ok)
+	}
+	if _, ok := table.Lookup("CANCELED"); ok {
+		t.Error("exact table should not match alias with different case")
+	}
+
+	if len(table.Aliases()) != 2 {
+		t.Errorf("expected 2 aliases, got %d", len(table.Aliases()))
+	}
+
+	// Labels are not affected
+	if label, _ := table.Label(1); label != "cancelled" {
+		t.Errorf("expected canonical label %q, got %q", "cancelled", label)
+	}
+}
+
+// TestTableLookupBytes tests byte slice lookups on small and indexed tables
+func TestTableLookupBytes(t *testing.T) {
+	small := DenseTable[int]([]string{"a", "b", "c"})
+	if val, ok := small.LookupBytes([]byte("b")); !ok || val != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", val, ok)
+	}
+
+	labels := make([]string, LinearIndexMax+5)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("label_%d", i)
+	}
+	large := DenseTable[int](labels)
+	if large.exact == nil {
+		t.Fatal("expected a table above LinearIndexMax to index its labels")
+	}
+	for i, label := range labels {
+		if val, ok := large.LookupBytes([]byte(label)); !ok || val != i {
+			t.Errorf("LookupBytes(%q) = (%d, %v), want (%d, true)", label, val, ok, i)
+		}
+		if val, ok := large.Lookup(label); !ok || val != i {
+			t.Errorf("Lookup(%q) = (%d, %v), want (%d, true)", label, val, ok, i)
+		}
+	}
+	if _, ok := large.LookupBytes([]byte("missing")); ok {
+		t.Error("expected unknown label not to be found")
+	}
+
+	aliased := small.WithAliases(Alias{Label: "bee", Index: 1})
+	if val, ok := aliased.LookupBytes([]byte("bee")); !ok || val != 1 {
+		t.Errorf("expected alias to resolve to (1, true), got (%d, %v)", val, ok)
+	}
+	relaxed := small.WithMode(ParseCaseInsensitive)
+	if val, ok := relaxed.LookupBytes([]byte("C")); !ok || val != 2 {
+		t.Errorf("expected (2, true), got (%d, %v)", val, ok)
+	}
+}
+
+// TestTableMarshalLabel tests strict and lenient labels of values that are not members
+func TestTableMarshalLabel(t *testing.T) {
+	type Code int16
+	table := NewTable([]string{"ok", "moved", "missing"}, []Code{200, 301, 404})
+
+	if label, err := table.MarshalLabel(301); err != nil || label != "moved" {
+		t.Errorf("expected moved, got %q (err %v)", label, err)
+	}
+
+	_, err := table.MarshalLabel(-5)
+	expected := &ErrValueOutOfRange{Value: "-5", Min: "200", Max: "404"}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("expected %+v, got %+v", expected, err)
+	}
+
+	lenient := table.WithLenientMarshal(true)
+	if !lenient.LenientMarshal() || table.LenientMarshal() {
+		t.Error("expected WithLenientMarshal to return a lenient copy")
+	}
+	if label, err := lenient.MarshalLabel(-5); err != nil || label != InvalidLabel {
+		t.Errorf("expected %q, got %q (err %v)", InvalidLabel, label, err)
+	}
+
+	letters := NewTable([]string{"b", "c", "a"}, []string{"b", "c", "a"})
+	if err := letters.RangeError("z"); err.Min != "a" || err.Max != "c" || err.Value != "z" {
+		t.Errorf("expected z out of [a, c], got %+v", err)
+	}
+}
+
+// generateLabels is a helper function to create test labels
+func generateLabels(count int) []string {
+	labels := make([]string, count)
+	for i := 0; i < count; i++ {
+		labels[i] = fmt.Sprintf("label_%d", i)
+	}
+	return labels
+}
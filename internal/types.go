@@ -0,0 +1,7 @@
+package internal
+
+// Integer is a type constraint for all signed and unsigned integer kinds.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
@@ -1,24 +1,27 @@
 package internal
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 // ValidateIndex checks if an index is within bounds for the given labels
-func ValidateIndex[T ~int](labels []string, index T) error {
-	i := int(index)
-	if i < 0 || i >= len(labels) {
-		return fmt.Errorf("index %d out of bounds for enum with %d labels", i, len(labels))
+func ValidateIndex[T Integer](labels []string, index T) error {
+	if !IsValidIndex(labels, index) {
+		return fmt.Errorf("index %d out of bounds for enum with %d labels", index, len(labels))
 	}
 	return nil
 }
 
 // IsValidIndex checks if an index is within bounds (returns bool instead of error)
-func IsValidIndex[T ~int](labels []string, index T) bool {
-	i := int(index)
-	return i >= 0 && i < len(labels)
+func IsValidIndex[T Integer](labels []string, index T) bool {
+	// Compare as uint64 so that large unsigned or 64-bit values are never
+	// truncated into a valid int index.
+	return index >= 0 && uint64(index) < uint64(len(labels))
 }
 
 // SafeGetLabel returns the label for an index, or a default value if invalid
-func SafeGetLabel[T ~int](labels []string, index T, defaultLabel string) string {
+func SafeGetLabel[T Integer](labels []string, index T, defaultLabel string) string {
 	if IsValidIndex(labels, index) {
 		return labels[int(index)]
 	}
@@ -26,9 +29,46 @@ func SafeGetLabel[T ~int](labels []string, index T, defaultLabel string) string
 }
 
 // SafeGetLabelWithError returns the label for an index, or an error if invalid
-func SafeGetLabelWithError[T ~int](labels []string, index T) (string, error) {
+func SafeGetLabelWithError[T Integer](labels []string, index T) (string, error) {
 	if err := ValidateIndex(labels, index); err != nil {
 		return "", err
 	}
 	return labels[int(index)], nil
 }
+
+// ValidateCapacity checks that every index of count labels can be represented by T.
+// For example, an int8 enum can hold at most 128 labels and a uint8 enum 256.
+func ValidateCapacity[T Integer](count int) error {
+	if count == 0 {
+		return nil
+	}
+	last := T(count - 1)
+	if last < 0 || uint64(last) != uint64(count-1) {
+		return NewTooManyLabelsError(count, MaxLabels[T]())
+	}
+	return nil
+}
+
+// MaxLabels returns the maximum number of labels whose indices T can represent,
+// capped at the maximum int value.
+func MaxLabels[T Integer]() int {
+	var zero T
+	if allOnes := zero - 1; allOnes > zero {
+		// Unsigned: every value from 0 to the maximum is a valid index.
+		if uint64(allOnes) >= uint64(math.MaxInt) {
+			return math.MaxInt
+		}
+		return int(allOnes) + 1
+	}
+
+	// Signed: find the highest positive power of two; the maximum is twice that minus one.
+	highBit := T(1)
+	for highBit<<1 > 0 {
+		highBit <<= 1
+	}
+	maxValue := uint64(highBit-1) + uint64(highBit)
+	if maxValue >= uint64(math.MaxInt) {
+		return math.MaxInt
+	}
+	return int(maxValue) + 1
+}
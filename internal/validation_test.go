@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"math"
 	"testing"
 )
 
@@ -287,3 +288,82 @@ func TestCustomTypes(t *testing.T) {
 		t.Errorf("expected 'custom2', got %q", result)
 	}
 }
+
+// TestIntegerKinds tests validation with every integer kind
+func TestIntegerKinds(t *testing.T) {
+	labels := []string{"a", "b", "c"}
+
+	if !IsValidIndex(labels, int8(2)) || IsValidIndex(labels, int8(-1)) {
+		t.Error("unexpected result for int8 index")
+	}
+	if !IsValidIndex(labels, uint16(1)) || IsValidIndex(labels, uint16(3)) {
+		t.Error("unexpected result for uint16 index")
+	}
+	if SafeGetLabel(labels, uint8(2), "default") != "c" {
+		t.Error("unexpected label for uint8 index")
+	}
+
+	// Values that would wrap to a valid int index must stay invalid
+	if IsValidIndex(labels, uint64(1<<63+1)) {
+		t.Error("large uint64 value should be out of bounds")
+	}
+	if IsValidIndex(labels, int64(-1<<62)) {
+		t.Error("large negative int64 value should be out of bounds")
+	}
+	if err := ValidateIndex(labels, ^uint64(0)); err == nil {
+		t.Error("expected error for max uint64 index")
+	}
+}
+
+// TestValidateCapacity tests label count checks against the value type
+func TestValidateCapacity(t *testing.T) {
+	tests := []struct {
+		name        string
+		check       func() error
+		expectError bool
+	}{
+		{name: "int8 at capacity", check: func() error { return ValidateCapacity[int8](128) }},
+		{name: "int8 over capacity", check: func() error { return ValidateCapacity[int8](129) }, expectError: true},
+		{name: "uint8 at capacity", check: func() error { return ValidateCapacity[uint8](256) }},
+		{name: "uint8 over capacity", check: func() error { return ValidateCapacity[uint8](257) }, expectError: true},
+		{name: "int16 small", check: func() error { return ValidateCapacity[int16](3) }},
+		{name: "uint64 large", check: func() error { return ValidateCapacity[uint64](1 << 20) }},
+		{name: "empty", check: func() error { return ValidateCapacity[int8](0) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check()
+			if tt.expectError && err == nil {
+				t.Error("expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
+// TestMaxLabels tests the maximum label count for each integer kind
+func TestMaxLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      int
+		expected int
+	}{
+		{name: "int8", got: MaxLabels[int8](), expected: 128},
+		{name: "uint8", got: MaxLabels[uint8](), expected: 256},
+		{name: "int16", got: MaxLabels[int16](), expected: 32768},
+		{name: "uint16", got: MaxLabels[uint16](), expected: 65536},
+		{name: "int64", got: MaxLabels[int64](), expected: math.MaxInt},
+		{name: "uint64", got: MaxLabels[uint64](), expected: math.MaxInt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, tt.got)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"encoding/binary"
+)
+
+// BinaryEncoding selects how enum values are written to binary.
+type BinaryEncoding uint8
+
+const (
+	// BinaryLabel writes the label, prefixed by its length on 2 bytes.
+	BinaryLabel BinaryEncoding = iota
+
+	// BinaryVarint writes a marker byte followed by the value as a varint.
+	BinaryVarint
+
+	// BinaryVarintFingerprint writes a marker byte, the enum fingerprint and
+	// the value as a varint.
+	BinaryVarintFingerprint
+)
+
+// Marker bytes of the varint encodings. As the first byte of the label
+// encoding, they would announce a label of at least 65024 bytes, which cannot
+// fit in maxVarintBinaryLen bytes, so both encodings can be told apart.
+const (
+	binaryVarintMarker      = 0xff
+	binaryFingerprintMarker = 0xfe
+	maxVarintBinaryLen      = 2 + binary.MaxVarintLen64
+)
+
+// Fingerprint summarizes the labels and values of a table in one byte.
+// It changes when a member is added, removed, renamed or renumbered.
+func Fingerprint[T Integer](t *Table[T]) byte {
+	h := uint32(2166136261)
+	write := func(b byte) {
+		h ^= uint32(b)
+		h *= 16777619
+	}
+	for i, label := range t.Labels() {
+		for j := 0; j < len(label); j++ {
+			write(label[j])
+		}
+		write(0)
+		v := uint64(t.Values()[i])
+		for j := 0; j < 8; j++ {
+			write(byte(v >> (8 * j)))
+		}
+	}
+	return byte(h) ^ byte(h>>8) ^ byte(h>>16) ^ byte(h>>24)
+}
+
+// ToBinaryAs serializes an enum value into binary using the given encoding.
+// The varint encodings reject values that do not belong to the table.
+func ToBinaryAs[T Integer](t *Table[T], v T, enc BinaryEncoding, fingerprint byte) ([]byte, error) {
+	if enc == BinaryLabel {
+		return ToBinary(t, v)
+	}
+	data, err := AppendBinaryAs(t, make([]byte, 0, maxVarintBinaryLen), v, enc, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// FromBinaryAs deserializes binary into an enum value. It reads every
+// encoding, whatever the one used for writing, so data can be migrated.
+// A fingerprint different from the given one is rejected.
+func FromBinaryAs[T Integer](t *Table[T], data []byte, fingerprint byte) (T, error) {
+	if !IsVarintBinary(data) {
+		return FromBinary(t, data)
+	}
+
+	var zero T
+	payload := data[1:]
+	if data[0] == binaryFingerprintMarker {
+		if payload[0] != fingerprint {
+			return zero, NewBinaryFingerprintMismatchError(fingerprint, payload[0])
+		}
+		payload = payload[1:]
+	}
+
+	v, n := readVarint[T](payload)
+	if n == 0 {
+		return zero, NewBinaryDataTruncatedError(len(data)+1, len(data))
+	}
+	if n < 0 || !t.Contains(v) {
+		return zero, NewInvalidEnumValueError(formatInteger(v), t.Labels())
+	}
+	return v, nil
+}
+
+// IsVarintBinary reports whether data uses a varint encoding.
+func IsVarintBinary(data []byte) bool {
+	if len(data) < 2 || len(data) > maxVarintBinaryLen {
+		return false
+	}
+	return data[0] == binaryVarintMarker || data[0] == binaryFingerprintMarker
+}
+
+// readVarint decodes a varint into T. Like binary.Varint, it returns n == 0
+// if buf is too small and n < 0 if the value does not fit.
+func readVarint[T Integer](buf []byte) (T, int) {
+	var zero T
+	if zero-1 < zero {
+		x, n := binary.Varint(buf)
+		if n > 0 && int64(T(x)) != x {
+			return T(x), -n
+		}
+		return T(x), n
+	}
+	x, n := binary.Uvarint(buf)
+	if n > 0 && uint64(T(x)) != x {
+		return T(x), -n
+	}
+	return T(x), n
+}
@@ -0,0 +1,99 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// TestToBinaryAs tests the size and layout of each binary encoding
+func TestToBinaryAs(t *testing.T) {
+	table := DenseTable[int]([]string{"low", "medium", "high", "critical"})
+	fp := Fingerprint(table)
+
+	label, err := ToBinaryAs(table, 3, BinaryLabel, fp)
+	if err != nil || len(label) != 10 {
+		t.Errorf("expected 10-byte label encoding, got %v (err %v)", label, err)
+	}
+
+	varint, err := ToBinaryAs(table, 3, BinaryVarint, fp)
+	if err != nil || !bytes.Equal(varint, []byte{0xff, 6}) {
+		t.Errorf("expected [0xff 6], got %v (err %v)", varint, err)
+	}
+
+	withFingerprint, err := ToBinaryAs(table, 3, BinaryVarintFingerprint, fp)
+	if err != nil || !bytes.Equal(withFingerprint, []byte{0xfe, fp, 6}) {
+		t.Errorf("expected [0xfe %d 6], got %v (err %v)", fp, withFingerprint, err)
+	}
+
+	if _, err := ToBinaryAs(table, 9, BinaryVarint, fp); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange for unknown value, got %v", err)
+	}
+
+	unsigned := NewTable([]string{"big"}, []uint64{1 << 63})
+	data, err := ToBinaryAs(unsigned, 1<<63, BinaryVarint, 0)
+	if err != nil {
+		t.Fatalf("ToBinaryAs failed: %v", err)
+	}
+	if v, err := FromBinaryAs(unsigned, data, 0); err != nil || v != 1<<63 {
+		t.Errorf("expected round trip of 1<<63, got %d (err %v)", v, err)
+	}
+}
+
+// TestFromBinaryAs tests that every encoding is read
+func TestFromBinaryAs(t *testing.T) {
+	table := NewTable([]string{"ok", "gone"}, []int16{200, -410})
+	fp := Fingerprint(table)
+
+	for _, enc := range []BinaryEncoding{BinaryLabel, BinaryVarint, BinaryVarintFingerprint} {
+		data, err := ToBinaryAs(table, -410, enc, fp)
+		if err != nil {
+			t.Fatalf("ToBinaryAs(%d) failed: %v", enc, err)
+		}
+		v, err := FromBinaryAs(table, data, fp)
+		if err != nil || v != -410 {
+			t.Errorf("encoding %d: expected -410, got %d (err %v)", enc, v, err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{"fingerprint mismatch", []byte{0xfe, fp + 1, 2}, &ErrBinaryFingerprintMismatch{}},
+		{"truncated varint", []byte{0xff, 0x80}, &ErrBinaryDataTruncated{}},
+		{"missing varint", []byte{0xfe, fp}, &ErrBinaryDataTruncated{}},
+		{"unknown value", []byte{0xff, 2}, &ErrInvalidEnumValue{}},
+		{"out of range", []byte{0xff, 0x80, 0x80, 0x04}, &ErrInvalidEnumValue{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromBinaryAs(table, tt.data, fp); !errors.Is(err, tt.err) {
+				t.Errorf("expected %T, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// TestFingerprint tests that the fingerprint follows the members
+func TestFingerprint(t *testing.T) {
+	base := Fingerprint(DenseTable[int]([]string{"a", "b", "c"}))
+	if base != Fingerprint(DenseTable[int]([]string{"a", "b", "c"})) {
+		t.Error("expected the same fingerprint for the same members")
+	}
+
+	changed := 0
+	for _, table := range []*Table[int]{
+		DenseTable[int]([]string{"a", "b"}),
+		DenseTable[int]([]string{"a", "b", "d"}),
+		NewTable([]string{"a", "b", "c"}, []int{0, 1, 3}),
+	} {
+		if Fingerprint(table) != base {
+			changed++
+		}
+	}
+	if changed == 0 {
+		t.Error("expected the fingerprint to change with the members")
+	}
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ToYAMLNode serializes an enum value into a YAML scalar node with the given style.
+// With comment, the node carries a line comment listing the valid labels.
+func ToYAMLNode[T comparable](t *Table[T], v T, style yaml.Style, comment bool) (*yaml.Node, error) {
+	label, err := t.MarshalLabel(v)
+	if err != nil {
+		return nil, err
+	}
+	node := YAMLScalar(label, style)
+	if comment {
+		node.LineComment = "one of: " + strings.Join(t.Labels(), ", ")
+	}
+	return node, nil
+}
+
+// YAMLScalar returns a string scalar node holding value.
+func YAMLScalar(value string, style yaml.Style) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: style}
+}
+
+// FromYAMLNode deserializes a YAML scalar node into an enum value.
+// An unknown label returns an *ErrInvalidEnumValue holding the position of the node.
+func FromYAMLNode[T comparable](t *Table[T], node *yaml.Node) (T, error) {
+	var zero T
+	if node.Kind != yaml.ScalarNode {
+		return zero, yamlKindError(node, "an enum label")
+	}
+	if val, found := t.Lookup(node.Value); found {
+		return val, nil
+	}
+	return zero, NewInvalidEnumValueErrorAt(node.Value, t.Labels(), node.Line, node.Column)
+}
+
+// FlagsFromYAMLNode deserializes a YAML sequence node of labels into a flag set.
+// An unknown label returns an *ErrInvalidEnumValue holding the position of its node.
+func FlagsFromYAMLNode[T Integer](t *Table[T], node *yaml.Node) (T, error) {
+	var zero T
+	if node.Kind != yaml.SequenceNode {
+		return zero, yamlKindError(node, "a sequence of flag labels")
+	}
+	names := make([]string, len(node.Content))
+	for i, item := range node.Content {
+		if item.Kind != yaml.ScalarNode {
+			return zero, yamlKindError(item, "a flag label")
+		}
+		names[i] = item.Value
+	}
+
+	v, err := ParseFlags(t, names)
+	var invalidErr *ErrInvalidEnumValue
+	if errors.As(err, &invalidErr) {
+		for _, item := range node.Content {
+			if item.Value == invalidErr.Value {
+				invalidErr.Line, invalidErr.Column = item.Line, item.Column
+				break
+			}
+		}
+	}
+	return v, err
+}
+
+// yamlKindError reports a node of the wrong kind the way the YAML decoder does,
+// so that it is collected with the other type errors of the document.
+func yamlKindError(node *yaml.Node, expected string) error {
+	return &yaml.TypeError{Errors: []string{
+		fmt.Sprintf("line %d: cannot unmarshal %s into %s", node.Line, node.ShortTag(), expected),
+	}}
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestToYAMLNode tests encoding labels as scalar nodes
+func TestToYAMLNode(t *testing.T) {
+	table := DenseTable[int]([]string{"low", "high"})
+
+	node, err := ToYAMLNode(table, 1, yaml.SingleQuotedStyle, true)
+	if err != nil {
+		t.Fatalf("ToYAMLNode failed: %v", err)
+	}
+	if node.Kind != yaml.ScalarNode || node.Value != "high" || node.Style != yaml.SingleQuotedStyle {
+		t.Errorf("unexpected node %+v", node)
+	}
+	if node.LineComment != "one of: low, high" {
+		t.Errorf("unexpected comment %q", node.LineComment)
+	}
+
+	if plain, _ := ToYAMLNode(table, 0, 0, false); plain.LineComment != "" {
+		t.Errorf("expected no comment, got %q", plain.LineComment)
+	}
+	if _, err := ToYAMLNode(table, 5, 0, false); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
+	}
+}
+
+// TestFromYAMLNode tests decoding scalar nodes
+func TestFromYAMLNode(t *testing.T) {
+	table := DenseTable[int]([]string{"low", "high"})
+
+	if v, err := FromYAMLNode(table, &yaml.Node{Kind: yaml.ScalarNode, Value: "high"}); err != nil || v != 1 {
+		t.Errorf("expected 1, got %d (err %v)", v, err)
+	}
+
+	_, err := FromYAMLNode(table, &yaml.Node{Kind: yaml.ScalarNode, Value: "medium", Line: 4, Column: 7})
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) || invalidErr.Line != 4 || invalidErr.Column != 7 {
+		t.Errorf("expected ErrInvalidEnumValue at 4:7, got %v", err)
+	}
+
+	_, err = FromYAMLNode(table, &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: 2})
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		t.Errorf("expected *yaml.TypeError for a mapping, got %v", err)
+	}
+}
+
+// TestFlagsFromYAMLNode tests decoding sequence nodes into flag sets
+func TestFlagsFromYAMLNode(t *testing.T) {
+	table := NewTable([]string{"read", "write", "exec"}, []uint8{1, 2, 4})
+	seq := func(items ...*yaml.Node) *yaml.Node {
+		return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: items}
+	}
+	scalar := func(value string, line int) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Line: line, Column: 3}
+	}
+
+	if v, err := FlagsFromYAMLNode(table, seq(scalar("read", 1), scalar("exec", 2))); err != nil || v != 5 {
+		t.Errorf("expected 5, got %d (err %v)", v, err)
+	}
+
+	_, err := FlagsFromYAMLNode(table, seq(scalar("read", 1), scalar("delete", 2)))
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) || invalidErr.Value != "delete" || invalidErr.Line != 2 {
+		t.Errorf("expected ErrInvalidEnumValue for delete at line 2, got %v", err)
+	}
+
+	if _, err := FlagsFromYAMLNode(table, seq(seq())); !errors.As(err, new(*yaml.TypeError)) {
+		t.Errorf("expected *yaml.TypeError for a nested sequence, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package enum
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NullWrapper wraps an enum value that may be null, in the spirit of sql.NullString.
+// Valid is false for SQL NULL, JSON null, YAML null or ~, and empty text or binary data.
+// Non-null input is decoded by Wrapper and returns the same errors.
+type NullWrapper[T Value] struct {
+	Wrapper Wrapper[T]
+	Valid   bool
+}
+
+// Ensure NullWrapper implements the necessary interfaces.
+var (
+	_ json.Marshaler             = (*NullWrapper[int])(nil)
+	_ json.Unmarshaler           = (*NullWrapper[int])(nil)
+	_ yaml.Marshaler             = (*NullWrapper[int])(nil)
+	_ yaml.Unmarshaler           = (*NullWrapper[int])(nil)
+	_ encoding.TextMarshaler     = (*NullWrapper[int])(nil)
+	_ encoding.TextUnmarshaler   = (*NullWrapper[int])(nil)
+	_ encoding.BinaryMarshaler   = (*NullWrapper[int])(nil)
+	_ encoding.BinaryUnmarshaler = (*NullWrapper[int])(nil)
+	_ driver.Valuer              = (*NullWrapper[int])(nil)
+	_ sql.Scanner                = (*NullWrapper[int])(nil)
+)
+
+// NewNullWrapper creates a new, null NullWrapper with the given labels.
+func NewNullWrapper[T Value](labels ...string) NullWrapper[T] {
+	return NullWrapper[T]{Wrapper: NewWrapper[T](labels...)}
+}
+
+// String returns the string representation of the wrapped value, or an empty string if null.
+func (n NullWrapper[T]) String() string {
+	if !n.Valid {
+		return ""
+	}
+	return n.Wrapper.String()
+}
+
+// Get returns the current value and whether it is not null.
+func (n NullWrapper[T]) Get() (T, bool) {
+	return n.Wrapper.Current, n.Valid
+}
+
+// Set sets the current value, clears any preserved unknown value and marks
+// it as not null.
+func (n *NullWrapper[T]) Set(v T) {
+	n.Wrapper.Set(v)
+	n.Valid = true
+}
+
+// SetNull marks the value as null and clears any preserved unknown value.
+func (n *NullWrapper[T]) SetNull() {
+	var zero T
+	n.Wrapper.Set(zero)
+	n.Valid = false
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n NullWrapper[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Wrapper.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *NullWrapper[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		n.SetNull()
+		return nil
+	}
+	return n.decode(n.Wrapper.UnmarshalJSON(data))
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (n NullWrapper[T]) MarshalYAML() (any, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Wrapper.MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// yaml.v3 does not call it for null nodes: it leaves a NullWrapper field
+// unchanged and sets a *NullWrapper field to nil. Decode into a fresh value,
+// or use a pointer field, for YAML null to read as null.
+func (n *NullWrapper[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		n.SetNull()
+		return nil
+	}
+	return n.decode(n.Wrapper.UnmarshalYAML(node))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (n NullWrapper[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.Wrapper.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (n *NullWrapper[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		n.SetNull()
+		return nil
+	}
+	return n.decode(n.Wrapper.UnmarshalText(text))
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// A null value is encoded as empty data.
+func (n NullWrapper[T]) MarshalBinary() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+	return n.Wrapper.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (n *NullWrapper[T]) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		n.SetNull()
+		return nil
+	}
+	return n.decode(n.Wrapper.UnmarshalBinary(data))
+}
+
+// Value implements driver.Valuer for SQL integration.
+func (n NullWrapper[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Wrapper.Value()
+}
+
+// Scan implements sql.Scanner for SQL integration.
+func (n *NullWrapper[T]) Scan(src any) error {
+	if src == nil {
+		n.SetNull()
+		return nil
+	}
+	return n.decode(n.Wrapper.Scan(src))
+}
+
+// decode marks the value as valid after a successful decode by the wrapper.
+func (n *NullWrapper[T]) decode(err error) error {
+	if err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}
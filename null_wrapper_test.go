@@ -0,0 +1,220 @@
+package enum
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestNullWrapperGetSet tests null state handling
+func TestNullWrapperGetSet(t *testing.T) {
+	status := NewNullWrapper[int]("pending", "active")
+
+	if _, valid := status.Get(); valid {
+		t.Error("new NullWrapper should be null")
+	}
+	if status.String() != "" {
+		t.Errorf("expected empty string for null, got %q", status.String())
+	}
+
+	status.Set(1)
+	if v, valid := status.Get(); !valid || v != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", v, valid)
+	}
+	if status.String() != "active" {
+		t.Errorf("expected %q, got %q", "active", status.String())
+	}
+
+	status.SetNull()
+	if v, valid := status.Get(); valid || v != 0 {
+		t.Errorf("expected (0, false), got (%d, %v)", v, valid)
+	}
+
+	// Set and SetNull clear a preserved unknown value
+	status.Wrapper = status.Wrapper.WithPreserveUnknown(true)
+	for _, reset := range []func(){func() { status.Set(1) }, status.SetNull} {
+		if err := json.Unmarshal([]byte(`"returned"`), &status); err != nil || !status.Wrapper.IsUnknown() {
+			t.Fatalf("expected unknown value to be preserved, got err %v", err)
+		}
+		reset()
+		if status.Wrapper.IsUnknown() {
+			t.Error("expected unknown value to be cleared")
+		}
+	}
+	if data, err := json.Marshal(status); err != nil || string(data) != "null" {
+		t.Errorf("expected null, got %s (err %v)", data, err)
+	}
+}
+
+// TestNullWrapperJSON tests JSON null round trips
+func TestNullWrapperJSON(t *testing.T) {
+	type payload struct {
+		Status NullWrapper[int] `json:"status"`
+	}
+
+	p := payload{Status: NewNullWrapper[int]("pending", "active")}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"status":null}` {
+		t.Errorf("expected null status, got %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"status":"pending"}`), &p); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if v, valid := p.Status.Get(); !valid || v != 0 {
+		t.Errorf("expected first label to be valid, got (%d, %v)", v, valid)
+	}
+
+	data, err = json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"status":"pending"}` {
+		t.Errorf("expected pending status, got %s", data)
+	}
+
+	if err := json.Unmarshal([]byte(`{"status":null}`), &p); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if _, valid := p.Status.Get(); valid {
+		t.Error("expected status to be null")
+	}
+
+	err = json.Unmarshal([]byte(`{"status":"deleted"}`), &p)
+	if !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+	if _, valid := p.Status.Get(); valid {
+		t.Error("failed decode should not mark the value as valid")
+	}
+}
+
+// TestNullWrapperSQL tests SQL NULL round trips
+func TestNullWrapperSQL(t *testing.T) {
+	status := NewNullWrapper[int]("pending", "active")
+
+	value, err := status.Value()
+	if err != nil || value != nil {
+		t.Errorf("expected (nil, nil) for null, got (%v, %v)", value, err)
+	}
+
+	if err := status.Scan("active"); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	value, err = status.Value()
+	if err != nil || value != "active" {
+		t.Errorf("expected active, got (%v, %v)", value, err)
+	}
+
+	if err := status.Scan(nil); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if _, valid := status.Get(); valid {
+		t.Error("expected NULL to be scanned as null")
+	}
+
+	if err := status.Scan("deleted"); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+}
+
+// TestNullWrapperYAML tests YAML null and ~ handling
+func TestNullWrapperYAML(t *testing.T) {
+	status := NewNullWrapper[int]("pending", "active")
+
+	yamlValue, err := status.MarshalYAML()
+	if err != nil || yamlValue != nil {
+		t.Errorf("expected (nil, nil) for null, got (%v, %v)", yamlValue, err)
+	}
+
+	if err := status.UnmarshalYAML(yamlScalar("active")); err != nil {
+		t.Fatalf("UnmarshalYAML failed: %v", err)
+	}
+	if v, valid := status.Get(); !valid || v != 1 {
+		t.Errorf("expected (1, true), got (%d, %v)", v, valid)
+	}
+
+	yamlValue, err = status.MarshalYAML()
+	if err != nil || yamlNodeValue(yamlValue) != "active" {
+		t.Errorf("expected active, got (%v, %v)", yamlValue, err)
+	}
+
+	// ~ and null both decode as null, in a fresh value or a pointer field
+	type Stage int
+	active := NewNullWrapper[Stage]("draft", "active")
+	active.Set(1)
+	for _, null := range []string{"~", "null"} {
+		var doc struct {
+			Status  NullWrapper[Stage]  `yaml:"status"`
+			Pointer *NullWrapper[Stage] `yaml:"pointer"`
+		}
+		doc.Pointer = &active
+		if err := yaml.Unmarshal([]byte("status: "+null+"\npointer: "+null+"\n"), &doc); err != nil {
+			t.Fatalf("Unmarshal failed: %v", err)
+		}
+		if _, valid := doc.Status.Get(); valid {
+			t.Errorf("expected YAML %s to decode as null", null)
+		}
+		if doc.Pointer != nil {
+			t.Errorf("expected YAML %s to reset the pointer field", null)
+		}
+	}
+
+	if err := status.UnmarshalYAML(yamlScalar("deleted")); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+}
+
+// TestNullWrapperTextBinary tests empty text and binary handling
+func TestNullWrapperTextBinary(t *testing.T) {
+	status := NewNullWrapper[int]("pending", "active")
+
+	text, err := status.MarshalText()
+	if err != nil || len(text) != 0 {
+		t.Errorf("expected empty text for null, got (%q, %v)", text, err)
+	}
+
+	if err := status.UnmarshalText([]byte("pending")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if v, valid := status.Get(); !valid || v != 0 {
+		t.Errorf("expected (0, true), got (%d, %v)", v, valid)
+	}
+
+	binaryData, err := status.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	decoded := NewNullWrapper[int]("pending", "active")
+	if err := decoded.UnmarshalBinary(binaryData); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if v, valid := decoded.Get(); !valid || v != 0 {
+		t.Errorf("expected (0, true), got (%d, %v)", v, valid)
+	}
+
+	if err := decoded.UnmarshalText(nil); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if _, valid := decoded.Get(); valid {
+		t.Error("expected empty text to decode as null")
+	}
+
+	binaryData, err = decoded.MarshalBinary()
+	if err != nil || len(binaryData) != 0 {
+		t.Errorf("expected empty binary for null, got (%v, %v)", binaryData, err)
+	}
+	if err := decoded.UnmarshalBinary(binaryData); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if _, valid := decoded.Get(); valid {
+		t.Error("expected empty binary to decode as null")
+	}
+}
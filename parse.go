@@ -0,0 +1,25 @@
+package enum
+
+import "github.com/gmllt/enum/internal"
+
+// ParseMode controls how FromString and every unmarshaller match input
+// against enum labels. Modes can be combined with bitwise OR, and String
+// and every marshaller keep emitting the canonical label.
+type ParseMode = internal.ParseMode
+
+const (
+	// ParseExact matches labels byte for byte. This is the default.
+	ParseExact = internal.ParseExact
+
+	// ParseCaseInsensitive matches labels using Unicode case folding,
+	// so "ACTIVE" and "Active" both match "active".
+	ParseCaseInsensitive = internal.ParseCaseInsensitive
+
+	// ParseTrimSpace ignores leading and trailing white space, so " active " matches "active".
+	ParseTrimSpace = internal.ParseTrimSpace
+
+	// ParseIgnoreSeparators treats snake_case, kebab-case and camelCase spellings
+	// as equivalent, so "in-progress" and "InProgress" both match "in_progress".
+	// It implies ParseCaseInsensitive.
+	ParseIgnoreSeparators = internal.ParseIgnoreSeparators
+)
@@ -0,0 +1,134 @@
+package enum
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// Custom types for parse mode testing to avoid registry conflicts
+type ParseModeTestType int
+
+// TestEnumWithParseMode tests FromString under each parse mode
+func TestEnumWithParseMode(t *testing.T) {
+	base := NewEnum[int]("pending", "in_progress", "done")
+
+	tests := []struct {
+		name        string
+		mode        ParseMode
+		input       string
+		expectedVal int
+		expectError bool
+	}{
+		{name: "exact match", mode: ParseExact, input: "done", expectedVal: 2},
+		{name: "exact rejects case", mode: ParseExact, input: "Done", expectError: true},
+		{name: "case insensitive", mode: ParseCaseInsensitive, input: "DONE", expectedVal: 2},
+		{name: "case insensitive rejects spaces", mode: ParseCaseInsensitive, input: " done ", expectError: true},
+		{name: "trim space", mode: ParseTrimSpace, input: " done\n", expectedVal: 2},
+		{name: "kebab", mode: ParseIgnoreSeparators, input: "in-progress", expectedVal: 1},
+		{name: "camel", mode: ParseIgnoreSeparators, input: "inProgress", expectedVal: 1},
+		{name: "combined", mode: ParseTrimSpace | ParseIgnoreSeparators, input: "  In-Progress ", expectedVal: 1},
+		{name: "unknown", mode: ParseTrimSpace | ParseIgnoreSeparators, input: "cancelled", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := base.WithParseMode(tt.mode)
+			result, err := e.FromString(tt.input)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+			if result != tt.expectedVal {
+				t.Errorf("expected %d, got %d", tt.expectedVal, result)
+			}
+			// String keeps emitting the canonical label
+			if e.String(result) != base.Labels()[tt.expectedVal] {
+				t.Errorf("expected canonical label %q, got %q", base.Labels()[tt.expectedVal], e.String(result))
+			}
+		})
+	}
+
+	if base.ParseMode() != ParseExact {
+		t.Error("WithParseMode should not modify the original enum")
+	}
+}
+
+// TestWrapperWithParseMode tests that every unmarshaller honours the parse mode
+func TestWrapperWithParseMode(t *testing.T) {
+	wrapper := NewWrapper[ParseModeTestType]("active", "suspended").WithParseMode(ParseCaseInsensitive | ParseTrimSpace)
+
+	binaryData := make([]byte, 2+len(" SUSPENDED"))
+	binary.BigEndian.PutUint16(binaryData, uint16(len(" SUSPENDED")))
+	copy(binaryData[2:], " SUSPENDED")
+
+	tests := []struct {
+		name      string
+		unmarshal func(*Wrapper[ParseModeTestType]) error
+	}{
+		{name: "UnmarshalJSON", unmarshal: func(w *Wrapper[ParseModeTestType]) error { return json.Unmarshal([]byte(`"Suspended "`), w) }},
+		{name: "UnmarshalYAML", unmarshal: func(w *Wrapper[ParseModeTestType]) error {
+			return w.UnmarshalYAML(yamlScalar("SUSPENDED"))
+		}},
+		{name: "UnmarshalText", unmarshal: func(w *Wrapper[ParseModeTestType]) error { return w.UnmarshalText([]byte("\tsuspended")) }},
+		{name: "UnmarshalBinary", unmarshal: func(w *Wrapper[ParseModeTestType]) error { return w.UnmarshalBinary(binaryData) }},
+		{name: "Scan", unmarshal: func(w *Wrapper[ParseModeTestType]) error { return w.Scan([]byte("SUSPENDED")) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := wrapper
+			w.Current = 0
+			if err := tt.unmarshal(&w); err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if w.Get() != 1 {
+				t.Errorf("expected 1, got %d", w.Get())
+			}
+
+			text, err := w.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText failed: %v", err)
+			}
+			if string(text) != "suspended" {
+				t.Errorf("expected canonical label %q, got %q", "suspended", text)
+			}
+		})
+	}
+
+	err := wrapper.UnmarshalText([]byte("deleted"))
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) || invalidErr.Value != "deleted" {
+		t.Errorf("expected ErrInvalidEnumValue for %q, got %v", "deleted", err)
+	}
+}
+
+// TestStringAndFlagsWithParseMode tests parse modes on string enums and flags
+func TestStringAndFlagsWithParseMode(t *testing.T) {
+	regions := NewStringWrapper[Region]("eu-west-1", "us-east-1").WithParseMode(ParseCaseInsensitive)
+	if err := regions.UnmarshalText([]byte("EU-WEST-1")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if regions.Get() != "eu-west-1" {
+		t.Errorf("expected canonical value %q, got %q", "eu-west-1", regions.Get())
+	}
+
+	perms := NewFlagsWrapper[Permission]("read", "write", "exec").WithParseMode(ParseCaseInsensitive | ParseTrimSpace)
+	if err := perms.UnmarshalText([]byte("READ | Write")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if perms.Get() != PermRead|PermWrite {
+		t.Errorf("expected 3, got %d", perms.Get())
+	}
+
+	v, err := perms.Enum.FromString("Exec")
+	if err != nil || v != PermExec {
+		t.Errorf("expected (4, nil), got (%d, %v)", v, err)
+	}
+}
@@ -0,0 +1,183 @@
+// Package protoenum maps Protocol Buffers enums onto enum.Enum, and
+// converts enum.Wrapper values to and from protobuf enum numbers.
+//
+// It is a separate package so that only programs using Protocol Buffers
+// depend on google.golang.org/protobuf. The protoc-gen-enum plugin follows
+// the same rules, so generated enums and enums built from descriptors agree.
+package protoenum
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gmllt/enum"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// LabelStyle selects the labels of an enum built from a protobuf descriptor.
+type LabelStyle uint8
+
+const (
+	// LabelName uses value names as declared, such as "ORDER_STATUS_PENDING".
+	// These are also the names written by protojson.
+	LabelName LabelStyle = iota
+
+	// LabelShort removes the prefix derived from the enum name and
+	// lower-cases the rest, so ORDER_STATUS_PENDING in enum OrderStatus
+	// becomes "pending". Names without the prefix are only lower-cased.
+	LabelShort
+)
+
+// Options controls how a protobuf enum is mapped.
+type Options struct {
+	// Labels selects how value names become labels.
+	Labels LabelStyle
+	// OmitUnspecified leaves out the zero value when it follows the
+	// UNSPECIFIED = 0 convention, together with its aliases. An unset field
+	// then marshals with *enum.ErrValueOutOfRange instead of as "unspecified".
+	OmitUnspecified bool
+}
+
+// Member is a protobuf enum value kept by Options, with its label.
+type Member struct {
+	Desc  protoreflect.EnumValueDescriptor
+	Label string
+	// Alias is true for a value declared with allow_alias after another
+	// value with the same number.
+	Alias bool
+}
+
+// Members returns the values of desc kept by opts, in declaration order.
+// It returns an error if two values get the same label, which LabelShort can
+// cause in proto2 files, or if no value is kept.
+func Members(desc protoreflect.EnumDescriptor, opts Options) ([]Member, error) {
+	var members []Member
+	seen := make(map[protoreflect.EnumNumber]bool)
+	labels := make(map[string]bool)
+	values := desc.Values()
+	for i := 0; i < values.Len(); i++ {
+		value := values.Get(i)
+		number := value.Number()
+		if opts.OmitUnspecified && number == 0 && IsUnspecified(values.ByNumber(0)) {
+			continue
+		}
+		label := Label(value, opts.Labels)
+		if labels[label] {
+			return nil, fmt.Errorf("%s: duplicate label %q", desc.FullName(), label)
+		}
+		labels[label] = true
+		members = append(members, Member{Desc: value, Label: label, Alias: seen[number]})
+		seen[number] = true
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("%s: no members left", desc.FullName())
+	}
+	return members, nil
+}
+
+// NewEnum creates a new Enum from a protobuf enum descriptor, using value
+// labels and numbers. Aliases declared with allow_alias become enum aliases
+// of their first value.
+// It panics with the error of Members, or if a number cannot be represented
+// by T, such as -1 for a uint8 enum.
+func NewEnum[T enum.Value](desc protoreflect.EnumDescriptor, opts Options) *enum.Enum[T] {
+	members, err := Members(desc, opts)
+	if err != nil {
+		panic(err)
+	}
+
+	var (
+		values  []enum.Member[T]
+		aliases []enum.Alias[T]
+	)
+	for _, m := range members {
+		number := m.Desc.Number()
+		v := T(number)
+		// T(number) round-trips for negative numbers in uint64 and uint, so
+		// the sign is checked as well.
+		if int64(v) != int64(number) || number < 0 && v > 0 {
+			panic(fmt.Errorf("protoenum: value %s = %d does not fit in %s", m.Desc.Name(), number, reflect.TypeFor[T]()))
+		}
+		if m.Alias {
+			aliases = append(aliases, enum.Alias[T]{Label: m.Label, Value: v})
+		} else {
+			values = append(values, enum.Member[T]{Label: m.Label, Value: v})
+		}
+	}
+
+	e := enum.NewEnumFromMembers(values...)
+	if len(aliases) > 0 {
+		e = e.WithAliases(aliases...)
+	}
+	return e
+}
+
+// Label returns the label of a protobuf enum value.
+func Label(value protoreflect.EnumValueDescriptor, style LabelStyle) string {
+	name := string(value.Name())
+	if style != LabelShort {
+		return name
+	}
+	if parent, ok := value.Parent().(protoreflect.EnumDescriptor); ok {
+		if trimmed := strings.TrimPrefix(name, valuePrefix(string(parent.Name()))); trimmed != "" {
+			name = trimmed
+		}
+	}
+	return strings.ToLower(name)
+}
+
+// IsUnspecified reports whether value is the zero value of its enum named
+// after the UNSPECIFIED convention, such as ORDER_STATUS_UNSPECIFIED.
+func IsUnspecified(value protoreflect.EnumValueDescriptor) bool {
+	if value.Number() != 0 {
+		return false
+	}
+	name := string(value.Name())
+	return name == "UNSPECIFIED" || strings.HasSuffix(name, "_UNSPECIFIED")
+}
+
+// Number returns the protobuf number of the value of w, to set an enum field
+// of a generated message. It returns the errors of Wrapper.Int64, and an
+// *enum.ErrValueOutOfRange if the value does not fit in an int32.
+func Number[T enum.Value](w enum.Wrapper[T]) (protoreflect.EnumNumber, error) {
+	n, err := w.Int64()
+	if err != nil {
+		return 0, err
+	}
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		return 0, enum.NewValueOutOfRangeError(strconv.FormatInt(n, 10),
+			strconv.Itoa(math.MinInt32), strconv.Itoa(math.MaxInt32))
+	}
+	return protoreflect.EnumNumber(n), nil
+}
+
+// SetNumber sets the value of w from the number of a protobuf enum field, as
+// returned by the Number method of generated enums. Protobuf enums are open,
+// so a number unknown to the enum returns an *enum.ErrInvalidEnumValue, or is
+// preserved when the enum preserves unknown values.
+func SetNumber[T enum.Value](w *enum.Wrapper[T], n protoreflect.EnumNumber) error {
+	return w.SetInt64(int64(n))
+}
+
+// valuePrefix returns the value name prefix of an enum in the protobuf
+// style guide: "ORDER_STATUS_" for OrderStatus and "HTTP_METHOD_" for HTTPMethod.
+func valuePrefix(enumName string) string {
+	var b strings.Builder
+	runes := []rune(enumName)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) && prev != '_' || unicode.IsUpper(prev) && nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	b.WriteByte('_')
+	return b.String()
+}
@@ -0,0 +1,229 @@
+package protoenum
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gmllt/enum"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// protoEnumDescriptor builds the descriptor of an enum declared in a proto3 file.
+func protoEnumDescriptor(t *testing.T, name string, values map[string]int32, order ...string) protoreflect.EnumDescriptor {
+	t.Helper()
+	return enumDescriptor(t, "proto3", name, values, order...)
+}
+
+// enumDescriptor builds the descriptor of an enum declared in a file of the given syntax.
+func enumDescriptor(t *testing.T, syntax, name string, values map[string]int32, order ...string) protoreflect.EnumDescriptor {
+	t.Helper()
+	enumProto := &descriptorpb.EnumDescriptorProto{Name: proto.String(name)}
+	numbers := make(map[int32]bool)
+	for _, valueName := range order {
+		if numbers[values[valueName]] {
+			enumProto.Options = &descriptorpb.EnumOptions{AllowAlias: proto.Bool(true)}
+		}
+		numbers[values[valueName]] = true
+		enumProto.Value = append(enumProto.Value, &descriptorpb.EnumValueDescriptorProto{
+			Name:   proto.String(valueName),
+			Number: proto.Int32(values[valueName]),
+		})
+	}
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:     proto.String(name + ".proto"),
+		Package:  proto.String("test"),
+		Syntax:   proto.String(syntax),
+		EnumType: []*descriptorpb.EnumDescriptorProto{enumProto},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewFile failed: %v", err)
+	}
+	return file.Enums().Get(0)
+}
+
+// orderStatusDescriptor returns an OrderStatus enum with an alias.
+func orderStatusDescriptor(t *testing.T) protoreflect.EnumDescriptor {
+	return protoEnumDescriptor(t, "OrderStatus", map[string]int32{
+		"ORDER_STATUS_UNSPECIFIED": 0,
+		"ORDER_STATUS_PENDING":     1,
+		"ORDER_STATUS_SHIPPED":     2,
+		"ORDER_STATUS_SENT":        2,
+	}, "ORDER_STATUS_UNSPECIFIED", "ORDER_STATUS_PENDING", "ORDER_STATUS_SHIPPED", "ORDER_STATUS_SENT")
+}
+
+func TestNewEnum(t *testing.T) {
+	desc := orderStatusDescriptor(t)
+
+	tests := []struct {
+		name     string
+		opts     Options
+		labels   []string
+		values   []int32
+		alias    string
+		aliasVal int32
+	}{
+		{
+			name:     "names",
+			labels:   []string{"ORDER_STATUS_UNSPECIFIED", "ORDER_STATUS_PENDING", "ORDER_STATUS_SHIPPED"},
+			values:   []int32{0, 1, 2},
+			alias:    "ORDER_STATUS_SENT",
+			aliasVal: 2,
+		},
+		{
+			name:     "short labels",
+			opts:     Options{Labels: LabelShort},
+			labels:   []string{"unspecified", "pending", "shipped"},
+			values:   []int32{0, 1, 2},
+			alias:    "sent",
+			aliasVal: 2,
+		},
+		{
+			name:     "omit unspecified",
+			opts:     Options{Labels: LabelShort, OmitUnspecified: true},
+			labels:   []string{"pending", "shipped"},
+			values:   []int32{1, 2},
+			alias:    "sent",
+			aliasVal: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEnum[int32](desc, tt.opts)
+			if !reflect.DeepEqual(e.Labels(), tt.labels) {
+				t.Errorf("expected labels %v, got %v", tt.labels, e.Labels())
+			}
+			if !reflect.DeepEqual(e.All(), tt.values) {
+				t.Errorf("expected values %v, got %v", tt.values, e.All())
+			}
+			if v, err := e.FromString(tt.alias); err != nil || v != tt.aliasVal {
+				t.Errorf("expected alias %s to parse as %d, got %d (err %v)", tt.alias, tt.aliasVal, v, err)
+			}
+			if label := e.String(tt.aliasVal); label == tt.alias {
+				t.Errorf("expected the canonical label for %d, got the alias %s", tt.aliasVal, label)
+			}
+		})
+	}
+
+	if NewEnum[int32](desc, Options{OmitUnspecified: true}).Contains(0) {
+		t.Error("expected the unspecified value to be omitted")
+	}
+
+	// Only the UNSPECIFIED convention is omitted
+	colors := protoEnumDescriptor(t, "Color", map[string]int32{"RED": 0, "GREEN": 1}, "RED", "GREEN")
+	if e := NewEnum[int8](colors, Options{OmitUnspecified: true}); !e.Contains(0) {
+		t.Error("expected RED to be kept")
+	}
+}
+
+func TestNewEnumOverflow(t *testing.T) {
+	desc := protoEnumDescriptor(t, "Delta", map[string]int32{"DELTA_ZERO": 0, "DELTA_DOWN": -1}, "DELTA_ZERO", "DELTA_DOWN")
+
+	for name, build := range map[string]func(){
+		"uint8":  func() { NewEnum[uint8](desc, Options{}) },
+		"uint64": func() { NewEnum[uint64](desc, Options{}) },
+		"uint":   func() { NewEnum[uint](desc, Options{}) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic for a negative number in a %s enum", name)
+				}
+			}()
+			build()
+		})
+	}
+
+	if e := NewEnum[int8](desc, Options{}); e.String(-1) != "DELTA_DOWN" {
+		t.Errorf("expected DELTA_DOWN, got %q", e.String(-1))
+	}
+}
+
+func TestNewEnumDuplicateLabels(t *testing.T) {
+	// proto3 rejects names that collide once the prefix is removed, proto2 does not
+	desc := enumDescriptor(t, "proto2", "Color", map[string]int32{"COLOR_RED": 0, "RED": 1}, "COLOR_RED", "RED")
+	if _, err := Members(desc, Options{Labels: LabelShort}); err == nil || !strings.Contains(err.Error(), `duplicate label "red"`) {
+		t.Errorf("expected a duplicate label error, got %v", err)
+	}
+	if _, err := Members(desc, Options{}); err != nil {
+		t.Errorf("expected value names to be unique, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for duplicate labels")
+		}
+	}()
+	NewEnum[int](desc, Options{Labels: LabelShort})
+}
+
+func TestValuePrefix(t *testing.T) {
+	tests := map[string]string{
+		"OrderStatus": "ORDER_STATUS_",
+		"HTTPMethod":  "HTTP_METHOD_",
+		"Color":       "COLOR_",
+		"ServerURL":   "SERVER_URL_",
+		"Level2":      "LEVEL2_",
+	}
+	for name, expected := range tests {
+		if prefix := valuePrefix(name); prefix != expected {
+			t.Errorf("valuePrefix(%q): expected %q, got %q", name, expected, prefix)
+		}
+	}
+
+	// Names without the prefix are only lower-cased
+	desc := protoEnumDescriptor(t, "Color", map[string]int32{"RED": 0, "COLOR_BLUE": 1}, "RED", "COLOR_BLUE")
+	if labels := NewEnum[int](desc, Options{Labels: LabelShort}).Labels(); !reflect.DeepEqual(labels, []string{"red", "blue"}) {
+		t.Errorf("expected [red blue], got %v", labels)
+	}
+}
+
+func TestNumber(t *testing.T) {
+	type Shipment int32
+	e := NewEnum[Shipment](orderStatusDescriptor(t), Options{Labels: LabelShort, OmitUnspecified: true})
+	w := enum.Wrapper[Shipment]{Enum: e}
+
+	if err := SetNumber(&w, 2); err != nil || w.String() != "shipped" {
+		t.Fatalf("expected shipped, got %s (err %v)", w.String(), err)
+	}
+	if n, err := Number(w); err != nil || n != 2 {
+		t.Errorf("expected 2, got %d (err %v)", n, err)
+	}
+
+	// Unknown numbers are rejected, and the unset zero value is not a member
+	if err := SetNumber(&w, 7); !errors.Is(err, &enum.ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+	w.Set(0)
+	if _, err := Number(w); !errors.Is(err, &enum.ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
+	}
+
+	// Numbers from newer producers are kept when preserving unknown values
+	lenient := w.WithPreserveUnknown(true)
+	if err := SetNumber(&lenient, 7); err != nil || !lenient.IsUnknown() {
+		t.Fatalf("expected 7 to be preserved, got err %v", err)
+	}
+	if n, err := Number(lenient); err != nil || n != 7 {
+		t.Errorf("expected 7, got %d (err %v)", n, err)
+	}
+	if err := lenient.UnmarshalText([]byte("returned")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if _, err := Number(lenient); !errors.Is(err, &enum.ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue for an unknown label, got %v", err)
+	}
+
+	// Members must fit in an int32
+	type Wide int64
+	wide := enum.NewWrapperFromMembers(enum.Member[Wide]{Label: "huge", Value: 1 << 40})
+	wide.Set(1 << 40)
+	if _, err := Number(wide); !errors.Is(err, &enum.ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
+	}
+}
@@ -1,25 +1,457 @@
 package enum
 
 import (
+	"context"
+	"errors"
+	"iter"
 	"reflect"
+	"slices"
+	"strings"
 	"sync"
 )
 
-var (
-	registry   = make(map[string][]string)
-	registryMu sync.RWMutex
+// ConflictPolicy decides what happens when a type that is already registered
+// is registered again with a different definition.
+type ConflictPolicy int
+
+const (
+	// ConflictError keeps the existing registration and returns ErrRegistryConflict.
+	ConflictError ConflictPolicy = iota
+	// ConflictPanic panics with ErrRegistryConflict.
+	ConflictPanic
+	// ConflictOverride replaces the existing registration.
+	ConflictOverride
+)
+
+// EnumKind tells which definition a registry entry holds.
+type EnumKind int
+
+const (
+	// KindLabels is a label-only registration made with Register.
+	KindLabels EnumKind = iota
+	// KindEnum is an *Enum[T].
+	KindEnum
+	// KindStringEnum is a *StringEnum[T].
+	KindStringEnum
+	// KindFlags is a *Flags[T].
+	KindFlags
 )
 
+// String returns the name of the kind.
+func (k EnumKind) String() string {
+	switch k {
+	case KindLabels:
+		return "labels"
+	case KindEnum:
+		return "enum"
+	case KindStringEnum:
+		return "string enum"
+	case KindFlags:
+		return "flags"
+	default:
+		return "unknown"
+	}
+}
+
+// RegisteredEnum describes a registry entry. It is a copy: changing it does
+// not affect the registry.
+type RegisteredEnum struct {
+	// Type is the registered type.
+	Type reflect.Type
+	// Name is the type name, such as "Status".
+	Name string
+	// Package is the import path of the package declaring the type.
+	Package string
+	// Kind tells which definition was registered.
+	Kind EnumKind
+	// Labels are the labels in declaration order.
+	Labels []string
+	// Values are the values of type T matching Labels, or nil for KindLabels.
+	Values []any
+	// ParseMode is the parse mode of the registered definition.
+	ParseMode ParseMode
+	// Representation is the JSON and SQL representation, for KindEnum only.
+	Representation Representation
+}
+
+// QualifiedName returns the fully-qualified type name, such as
+// "github.com/acme/orders.Status", or the type string for unnamed types.
+func (r RegisteredEnum) QualifiedName() string {
+	if r.Name == "" {
+		return r.Type.String()
+	}
+	if r.Package == "" {
+		return r.Name
+	}
+	return r.Package + "." + r.Name
+}
+
+// clone returns a copy of r that shares no slices with it.
+func (r RegisteredEnum) clone() RegisteredEnum {
+	r.Labels = slices.Clone(r.Labels)
+	r.Values = slices.Clone(r.Values)
+	return r
+}
+
+// RegistrySnapshot is an immutable copy of the registry at a point in time.
+type RegistrySnapshot struct {
+	entries []RegisteredEnum
+}
+
+// Len returns the number of registered types.
+func (s RegistrySnapshot) Len() int {
+	return len(s.entries)
+}
+
+// All iterates over the entries, ordered by qualified name.
+func (s RegistrySnapshot) All() iter.Seq[RegisteredEnum] {
+	return func(yield func(RegisteredEnum) bool) {
+		for _, entry := range s.entries {
+			if !yield(entry.clone()) {
+				return
+			}
+		}
+	}
+}
+
+// Lookup returns the entry whose qualified name is name.
+func (s RegistrySnapshot) Lookup(name string) (RegisteredEnum, bool) {
+	for _, entry := range s.entries {
+		if entry.QualifiedName() == name {
+			return entry.clone(), true
+		}
+	}
+	return RegisteredEnum{}, false
+}
+
+// registration is the definition recorded for a type.
+type registration struct {
+	entry RegisteredEnum
+	// enum holds the *Enum[T], *StringEnum[T] or *Flags[T], nil for KindLabels.
+	enum any
+}
+
+// Definition is an enum definition that can be stored in a Registry:
+// an *Enum[T], a *StringEnum[T] or a *Flags[T].
+type Definition interface {
+	registryType() reflect.Type
+	registryEntry() RegisteredEnum
+}
+
+// Registry maps types to their enum definitions. Zero-value wrappers resolve
+// their enum from a registry, the default one unless told otherwise.
+// The zero value is an empty registry ready to use. A Registry is safe for
+// concurrent use and must not be copied after first use.
+type Registry struct {
+	mu      sync.RWMutex
+	entries map[reflect.Type]registration
+	sealed  bool
+	// divergences records every attempt to register a different definition
+	// for an already registered type, whatever the outcome.
+	divergences []*ErrRegistryConflict
+}
+
+// NewRegistry creates an empty registry, isolated from the default one.
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[reflect.Type]registration)}
+}
+
+// defaultRegistry backs the package-level registry functions.
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the global registry used by the package-level
+// functions, the wrapper constructors and wrappers without a registry.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register records def as the definition of its type.
+// Registering the same labels and values again replaces the stored definition;
+// a different definition is handled according to policy. Once the registry is
+// sealed, only the same definition is accepted, and then left unchanged.
+func (r *Registry) Register(def Definition, policy ConflictPolicy) error {
+	return r.register(def.registryType(), registration{entry: def.registryEntry(), enum: def}, policy)
+}
+
+// Lookup returns the entry registered for t.
+func (r *Registry) Lookup(t reflect.Type) (RegisteredEnum, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reg, ok := r.entries[t]
+	return reg.entry.clone(), ok
+}
+
+// LookupName returns the entry registered under the fully-qualified
+// type name, such as "github.com/acme/orders.Status".
+func (r *Registry) LookupName(name string) (RegisteredEnum, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, reg := range r.entries {
+		if reg.entry.QualifiedName() == name {
+			return reg.entry.clone(), true
+		}
+	}
+	return RegisteredEnum{}, false
+}
+
+// Unregister removes the entry of t and reports whether there was one.
+// It does nothing on a sealed registry.
+func (r *Registry) Unregister(t reflect.Type) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.entries[t]
+	if r.sealed {
+		return false
+	}
+	delete(r.entries, t)
+	return ok
+}
+
+// Seal freezes the registry, typically at the end of startup. Afterwards,
+// registering a different definition fails with ErrRegistrySealed, so
+// zero-value wrappers keep decoding the same way.
+func (r *Registry) Seal() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sealed = true
+}
+
+// Sealed reports whether Seal was called.
+func (r *Registry) Sealed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.sealed
+}
+
+// Verify reports every attempt to register a different definition for an
+// already registered type, such as two NewWrapper calls with divergent labels,
+// as an *ErrRegistryConflict per divergence joined with errors.Join.
+// It returns nil if there was none.
+func (r *Registry) Verify() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	errs := make([]error, len(r.divergences))
+	for i, d := range r.divergences {
+		errs[i] = d
+	}
+	return errors.Join(errs...)
+}
+
+// All iterates over every registered type, ordered by qualified name.
+// It works on a snapshot, so registering from the loop body is safe.
+func (r *Registry) All() iter.Seq[RegisteredEnum] {
+	return r.Snapshot().All()
+}
+
+// Snapshot returns an immutable copy of the registry.
+func (r *Registry) Snapshot() RegistrySnapshot {
+	r.mu.RLock()
+	entries := make([]RegisteredEnum, 0, len(r.entries))
+	for _, reg := range r.entries {
+		entries = append(entries, reg.entry.clone())
+	}
+	r.mu.RUnlock()
+
+	slices.SortFunc(entries, func(a, b RegisteredEnum) int {
+		return strings.Compare(a.QualifiedName(), b.QualifiedName())
+	})
+	return RegistrySnapshot{entries: entries}
+}
+
+// register stores reg under key, applying policy if key holds a different definition.
+func (r *Registry) register(key reflect.Type, reg registration, policy ConflictPolicy) error {
+	reg.entry.Type = key
+	reg.entry.Name = key.Name()
+	reg.entry.Package = key.PkgPath()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.entries[key]
+	same := ok && existing.entry.sameDefinition(reg.entry)
+	var err error
+	switch {
+	case ok && !same:
+		conflict := NewRegistryConflictError(key.String(), existing.entry.Labels, reg.entry.Labels)
+		r.recordDivergence(conflict)
+		if r.sealed {
+			err = NewRegistrySealedError(key.String())
+		} else if policy != ConflictOverride {
+			err = conflict
+		}
+	case r.sealed && same:
+		return nil
+	case r.sealed:
+		err = NewRegistrySealedError(key.String())
+	}
+	if err != nil {
+		if policy == ConflictPanic {
+			panic(err)
+		}
+		return err
+	}
+	if r.entries == nil {
+		r.entries = make(map[reflect.Type]registration)
+	}
+	r.entries[key] = reg
+	return nil
+}
+
+// recordDivergence adds conflict to the divergences unless the same label
+// sets were already recorded for its type. The caller holds r.mu.
+func (r *Registry) recordDivergence(conflict *ErrRegistryConflict) {
+	for _, d := range r.divergences {
+		if d.Type == conflict.Type && slices.Equal(d.Existing, conflict.Existing) && slices.Equal(d.Labels, conflict.Labels) {
+			return
+		}
+	}
+	r.divergences = append(r.divergences, conflict)
+}
+
+// registryContextKey is the context key of the registry.
+type registryContextKey struct{}
+
+// ContextWithRegistry returns a copy of ctx carrying r.
+func ContextWithRegistry(ctx context.Context, r *Registry) context.Context {
+	return context.WithValue(ctx, registryContextKey{}, r)
+}
+
+// RegistryFromContext returns the registry carried by ctx, or the default registry.
+func RegistryFromContext(ctx context.Context) *Registry {
+	if r, ok := ctx.Value(registryContextKey{}).(*Registry); ok && r != nil {
+		return r
+	}
+	return defaultRegistry
+}
+
+// Register records labels for T in the default registry, replacing any
+// previous registration. It panics with an *ErrRegistrySealed if the default
+// registry is sealed and labels differ from the registered ones. Use
+// RegisterEnum to register a full definition with a conflict policy.
 func Register[T any](labels ...string) {
-	t := reflect.TypeOf((*T)(nil)).Elem().Name()
-	registryMu.Lock()
-	defer registryMu.Unlock()
-	registry[t] = labels
+	reg := registration{entry: RegisteredEnum{Kind: KindLabels, Labels: slices.Clone(labels)}}
+	if err := defaultRegistry.register(typeKey[T](), reg, ConflictOverride); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterEnum records e as the definition of T in the default registry.
+// See Registry.Register.
+func RegisterEnum[T Value](e *Enum[T], policy ConflictPolicy) error {
+	return defaultRegistry.Register(e, policy)
 }
 
+// GetLabels returns the labels registered for T in the default registry,
+// or nil if T is not registered.
 func GetLabels[T any]() []string {
-	t := reflect.TypeOf((*T)(nil)).Elem().Name()
-	registryMu.RLock()
-	defer registryMu.RUnlock()
-	return registry[t]
+	reg, _ := lookupRegistration[T](defaultRegistry)
+	return reg.entry.Labels
+}
+
+// Registered iterates over every type of the default registry, ordered by qualified name.
+func Registered() iter.Seq[RegisteredEnum] {
+	return defaultRegistry.All()
+}
+
+// LookupRegistered returns the entry of the default registry registered under
+// the fully-qualified type name, such as "github.com/acme/orders.Status".
+func LookupRegistered(name string) (RegisteredEnum, bool) {
+	return defaultRegistry.LookupName(name)
+}
+
+// Snapshot returns an immutable copy of the default registry.
+func Snapshot() RegistrySnapshot {
+	return defaultRegistry.Snapshot()
+}
+
+// Seal freezes the default registry. See Registry.Seal.
+func Seal() {
+	defaultRegistry.Seal()
+}
+
+// VerifyRegistry reports divergent registrations in the default registry.
+// See Registry.Verify.
+func VerifyRegistry() error {
+	return defaultRegistry.Verify()
+}
+
+// typeKey returns the registry key of T. Unlike the type name, it tells apart
+// types of the same name in different packages and generic instantiations.
+func typeKey[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// lookupRegistration returns the registration of T in r.
+func lookupRegistration[T any](r *Registry) (registration, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reg, ok := r.entries[typeKey[T]()]
+	return reg, ok
+}
+
+// sameDefinition reports whether r and other describe the same enum.
+// Values are only compared when both entries carry them.
+func (r RegisteredEnum) sameDefinition(other RegisteredEnum) bool {
+	if !slices.Equal(r.Labels, other.Labels) {
+		return false
+	}
+	if r.Values == nil || other.Values == nil {
+		return true
+	}
+	return slices.Equal(r.Values, other.Values)
+}
+
+// registryType returns the type e is registered for.
+func (e *Enum[T]) registryType() reflect.Type {
+	return typeKey[T]()
+}
+
+// registryEntry describes e for the registry.
+func (e *Enum[T]) registryEntry() RegisteredEnum {
+	return RegisteredEnum{
+		Kind:           KindEnum,
+		Labels:         e.Labels(),
+		Values:         anySlice(e.allVals),
+		ParseMode:      e.ParseMode(),
+		Representation: e.repr,
+	}
+}
+
+// registryType returns the type e is registered for.
+func (e *StringEnum[T]) registryType() reflect.Type {
+	return typeKey[T]()
+}
+
+// registryEntry describes e for the registry.
+func (e *StringEnum[T]) registryEntry() RegisteredEnum {
+	return RegisteredEnum{
+		Kind:      KindStringEnum,
+		Labels:    e.Labels(),
+		Values:    anySlice(e.allVals),
+		ParseMode: e.ParseMode(),
+	}
+}
+
+// registryType returns the type f is registered for.
+func (f *Flags[T]) registryType() reflect.Type {
+	return typeKey[T]()
+}
+
+// registryEntry describes f for the registry.
+func (f *Flags[T]) registryEntry() RegisteredEnum {
+	return RegisteredEnum{
+		Kind:      KindFlags,
+		Labels:    f.Labels(),
+		Values:    anySlice(f.allVals),
+		ParseMode: f.ParseMode(),
+	}
+}
+
+// anySlice converts values to a []any.
+func anySlice[T any](values []T) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
 }
@@ -1,7 +1,12 @@
 package enum
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -128,3 +133,555 @@ func TestEnsureEnumLocalVsRegistry(t *testing.T) {
 		t.Errorf("expected enum labels from local %v, got %v", localLabels, wrapper.Enum.labels)
 	}
 }
+
+func TestRegistryKeyedByType(t *testing.T) {
+	// Two distinct types sharing a name must not overwrite each other
+	first := func() []string {
+		type Status int
+		Register[Status]("draft", "sent")
+		return GetLabels[Status]()
+	}
+	second := func() []string {
+		type Status int
+		Register[Status]("active", "banned")
+		return GetLabels[Status]()
+	}
+
+	second()
+	if labels := first(); !reflect.DeepEqual(labels, []string{"draft", "sent"}) {
+		t.Errorf("expected first Status labels, got %v", labels)
+	}
+	if labels := second(); !reflect.DeepEqual(labels, []string{"active", "banned"}) {
+		t.Errorf("expected second Status labels, got %v", labels)
+	}
+}
+
+func TestRegisterEnumConflictPolicy(t *testing.T) {
+	type Level int
+	low := NewEnum[Level]("low", "high")
+
+	if err := RegisterEnum(low, ConflictError); err != nil {
+		t.Fatalf("RegisterEnum failed: %v", err)
+	}
+
+	// Registering the same definition again is not a conflict
+	if err := RegisterEnum(NewEnum[Level]("low", "high"), ConflictError); err != nil {
+		t.Errorf("expected identical definition to be accepted, got %v", err)
+	}
+
+	other := NewEnum[Level]("off", "on")
+	var conflict *ErrRegistryConflict
+	if err := RegisterEnum(other, ConflictError); !errors.As(err, &conflict) {
+		t.Fatalf("expected ErrRegistryConflict, got %v", err)
+	}
+	if !reflect.DeepEqual(conflict.Existing, []string{"low", "high"}) {
+		t.Errorf("unexpected existing labels %v", conflict.Existing)
+	}
+	if labels := GetLabels[Level](); !reflect.DeepEqual(labels, []string{"low", "high"}) {
+		t.Errorf("expected registration to be kept, got %v", labels)
+	}
+
+	// Same labels with different values is a conflict too
+	moved := NewEnumFromMembers(Member[Level]{Label: "low", Value: 1}, Member[Level]{Label: "high", Value: 5})
+	if err := RegisterEnum(moved, ConflictError); !errors.Is(err, &ErrRegistryConflict{}) {
+		t.Errorf("expected ErrRegistryConflict for different values, got %v", err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected ConflictPanic to panic")
+			}
+		}()
+		_ = RegisterEnum(other, ConflictPanic)
+	}()
+
+	if err := RegisterEnum(other, ConflictOverride); err != nil {
+		t.Fatalf("ConflictOverride failed: %v", err)
+	}
+	if labels := GetLabels[Level](); !reflect.DeepEqual(labels, []string{"off", "on"}) {
+		t.Errorf("expected overridden labels, got %v", labels)
+	}
+}
+
+func TestZeroWrapperUsesRegisteredEnum(t *testing.T) {
+	type Code int
+	e := NewEnumFromMembers(Member[Code]{Label: "ok", Value: 200}, Member[Code]{Label: "missing", Value: 404}).
+		WithParseMode(ParseCaseInsensitive)
+	if err := RegisterEnum(e, ConflictError); err != nil {
+		t.Fatalf("RegisterEnum failed: %v", err)
+	}
+
+	var w Wrapper[Code]
+	if err := json.Unmarshal([]byte(`"MISSING"`), &w); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if w.Get() != 404 {
+		t.Errorf("expected 404, got %d", w.Get())
+	}
+}
+
+func TestZeroWrapperDefersOptions(t *testing.T) {
+	type Level int
+	type Zone string
+	type Scope uint8
+
+	// Options set before T is registered apply once the enum is resolved
+	w := Wrapper[Level]{}.WithRepresentation(RepresentNumber)
+	zone := StringWrapper[Zone]{}.WithParseMode(ParseCaseInsensitive)
+	scope := FlagsWrapper[Scope]{}.WithParseMode(ParseTrimSpace)
+	if w.Enum != nil || zone.Enum != nil || scope.Enum != nil {
+		t.Fatal("expected the enums to be resolved lazily")
+	}
+	_ = RegisterEnum(NewEnum[Level]("low", "high"), ConflictError)
+	_ = DefaultRegistry().Register(NewStringEnum[Zone]("eu", "us"), ConflictError)
+	_ = DefaultRegistry().Register(NewFlags[Scope]("read", "write"), ConflictError)
+
+	if err := json.Unmarshal([]byte(`1`), &w); err != nil || w.Get() != 1 {
+		t.Errorf("expected 1, got %d (err %v)", w.Get(), err)
+	}
+	if err := zone.UnmarshalText([]byte("EU")); err != nil || zone.Get() != "eu" {
+		t.Errorf("expected eu, got %q (err %v)", zone.Get(), err)
+	}
+	if err := scope.UnmarshalText([]byte(" read | write ")); err != nil || scope.Get() != 3 {
+		t.Errorf("expected 3, got %d (err %v)", scope.Get(), err)
+	}
+}
+
+func TestZeroWrapperRegisteredKind(t *testing.T) {
+	type Perm uint8
+	type Mode int
+	NewFlagsWrapper[Perm]("read", "write", "exec")
+	NewWrapper[Mode]("off", "on")
+
+	var w Wrapper[Perm]
+	if err := w.UnmarshalText([]byte("exec")); !errors.Is(err, &ErrRegistryKindMismatch{}) {
+		t.Errorf("expected ErrRegistryKindMismatch, got %v (value %d)", err, w.Get())
+	}
+	var f FlagsWrapper[Mode]
+	if err := f.UnmarshalText([]byte("on")); !errors.Is(err, &ErrRegistryKindMismatch{}) {
+		t.Errorf("expected ErrRegistryKindMismatch, got %v (value %d)", err, f.Get())
+	}
+
+	// Labels registered with Register are checked against the capacity of T
+	type Small int8
+	type Bits uint8
+	labels := make([]string, 200)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("label_%d", i)
+	}
+	Register[Small](labels...)
+	Register[Bits](labels[:9]...)
+	var small Wrapper[Small]
+	if err := small.UnmarshalText([]byte("label_1")); !errors.Is(err, &ErrTooManyLabels{}) {
+		t.Errorf("expected ErrTooManyLabels, got %v", err)
+	}
+	var bits FlagsWrapper[Bits]
+	if err := bits.UnmarshalText([]byte("label_1")); !errors.Is(err, &ErrTooManyLabels{}) {
+		t.Errorf("expected ErrTooManyLabels, got %v", err)
+	}
+}
+
+func TestZeroWrapperMarshalResolvesEnum(t *testing.T) {
+	type Tier int
+	Register[Tier]("free", "pro")
+
+	payload := struct {
+		Tier Wrapper[Tier] `json:"tier"`
+	}{}
+	payload.Tier.Set(1)
+	data, err := json.Marshal(payload)
+	if err != nil || string(data) != `{"tier":"pro"}` {
+		t.Errorf(`expected {"tier":"pro"}, got %s (err %v)`, data, err)
+	}
+}
+
+func TestZeroWrapperStringResolvesEnum(t *testing.T) {
+	type Plan int
+	type Region string
+	type Scope uint8
+	Register[Plan]("free", "pro")
+	if err := DefaultRegistry().Register(NewStringEnum[Region]("eu", "us"), ConflictOverride); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := DefaultRegistry().Register(NewFlags[Scope]("read", "write"), ConflictOverride); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	var w Wrapper[Plan]
+	w.Set(1)
+	if w.String() != "pro" || len(w.All()) != 2 || len(w.Labels()) != 2 {
+		t.Errorf("expected pro with 2 members, got %s %v %v", w.String(), w.All(), w.Labels())
+	}
+	if printed := fmt.Sprintf("%v", struct{ Plan Wrapper[Plan] }{w}); printed != "{pro}" {
+		t.Errorf("expected {pro}, got %s", printed)
+	}
+
+	s := StringWrapper[Region]{Current: "us"}
+	if s.String() != "us" || len(s.All()) != 2 || len(s.Labels()) != 2 {
+		t.Errorf("expected us with 2 members, got %s %v %v", s.String(), s.All(), s.Labels())
+	}
+	f := FlagsWrapper[Scope]{Current: 3}
+	if f.String() != "read|write" || len(f.All()) != 2 || len(f.Labels()) != 2 {
+		t.Errorf("expected read|write with 2 flags, got %s %v %v", f.String(), f.All(), f.Labels())
+	}
+	if split := f.Split(); !reflect.DeepEqual(split, []Scope{1, 2}) {
+		t.Errorf("expected [1 2], got %v", split)
+	}
+
+	// Unconfigured wrappers do not panic either
+	type Unregistered int
+	var u Wrapper[Unregistered]
+	u.Set(1)
+	if u.String() != "Invalid(1)" || u.All() != nil || u.Labels() != nil {
+		t.Errorf("expected Invalid(1) without members, got %s %v %v", u.String(), u.All(), u.Labels())
+	}
+	uf := FlagsWrapper[Unregistered]{Current: 3}
+	if uf.Split() != nil {
+		t.Errorf("expected no flags, got %v", uf.Split())
+	}
+}
+
+func TestZeroWrapperNotConfigured(t *testing.T) {
+	type Unregistered int
+	type UnregisteredString string
+	var w Wrapper[Unregistered]
+
+	marshallers := map[string]func() error{
+		"MarshalJSON":   func() error { _, err := w.MarshalJSON(); return err },
+		"MarshalYAML":   func() error { _, err := w.MarshalYAML(); return err },
+		"MarshalText":   func() error { _, err := w.MarshalText(); return err },
+		"MarshalBinary": func() error { _, err := w.MarshalBinary(); return err },
+		"Value":         func() error { _, err := w.Value(); return err },
+		"UnmarshalJSON": func() error { return w.UnmarshalJSON([]byte(`"a"`)) },
+		"Scan":          func() error { return w.Scan("a") },
+	}
+	for name, call := range marshallers {
+		if err := call(); !errors.Is(err, &ErrEnumNotConfigured{}) {
+			t.Errorf("%s: expected ErrEnumNotConfigured, got %v", name, err)
+		}
+	}
+
+	if _, err := json.Marshal(struct{ W Wrapper[Unregistered] }{}); !errors.Is(err, &ErrEnumNotConfigured{}) {
+		t.Errorf("expected json.Marshal to return ErrEnumNotConfigured, got %v", err)
+	}
+
+	var s StringWrapper[UnregisteredString]
+	if _, err := s.MarshalJSON(); !errors.Is(err, &ErrEnumNotConfigured{}) {
+		t.Errorf("StringWrapper: expected ErrEnumNotConfigured, got %v", err)
+	}
+	var f FlagsWrapper[Unregistered]
+	if _, err := f.MarshalText(); !errors.Is(err, &ErrEnumNotConfigured{}) {
+		t.Errorf("FlagsWrapper: expected ErrEnumNotConfigured, got %v", err)
+	}
+}
+
+// RegistryIntrospectionType is registered by the introspection tests only.
+type RegistryIntrospectionType int
+
+func TestRegistryIntrospection(t *testing.T) {
+	e := NewEnumFromMembers(
+		Member[RegistryIntrospectionType]{Label: "small", Value: 1},
+		Member[RegistryIntrospectionType]{Label: "large", Value: 3},
+	).WithParseMode(ParseCaseInsensitive).WithRepresentation(RepresentNumber)
+	if err := RegisterEnum(e, ConflictOverride); err != nil {
+		t.Fatalf("RegisterEnum failed: %v", err)
+	}
+
+	const name = "github.com/gmllt/enum.RegistryIntrospectionType"
+	entry, ok := LookupRegistered(name)
+	if !ok {
+		t.Fatalf("expected %s to be registered", name)
+	}
+	if entry.Name != "RegistryIntrospectionType" || entry.Package != "github.com/gmllt/enum" {
+		t.Errorf("unexpected name %q and package %q", entry.Name, entry.Package)
+	}
+	if entry.Kind != KindEnum || entry.Kind.String() != "enum" {
+		t.Errorf("expected KindEnum, got %v", entry.Kind)
+	}
+	if !reflect.DeepEqual(entry.Labels, []string{"small", "large"}) {
+		t.Errorf("unexpected labels %v", entry.Labels)
+	}
+	if !reflect.DeepEqual(entry.Values, []any{RegistryIntrospectionType(1), RegistryIntrospectionType(3)}) {
+		t.Errorf("unexpected values %v", entry.Values)
+	}
+	if entry.ParseMode != ParseCaseInsensitive || entry.Representation != RepresentNumber {
+		t.Errorf("unexpected metadata %v %v", entry.ParseMode, entry.Representation)
+	}
+
+	// Returned entries are copies
+	entry.Labels[0] = "modified"
+	if again, _ := LookupRegistered(name); again.Labels[0] != "small" {
+		t.Error("modifying a returned entry should not affect the registry")
+	}
+
+	if _, ok := LookupRegistered("github.com/gmllt/enum.Missing"); ok {
+		t.Error("expected unknown name not to be found")
+	}
+}
+
+func TestRegistrySnapshot(t *testing.T) {
+	type Site string
+	type Shift int
+	NewStringWrapper[Site]("eu", "us")
+	Register[Shift]("day", "night")
+	snapshot := Snapshot()
+
+	// Later registrations do not change the snapshot
+	Register[Shift]("changed")
+	entry, ok := snapshot.Lookup("github.com/gmllt/enum.Site")
+	if !ok || entry.Kind != KindStringEnum {
+		t.Fatalf("expected Site string enum in snapshot, got %+v (ok %v)", entry, ok)
+	}
+	if after, _ := snapshot.Lookup("github.com/gmllt/enum.Shift"); reflect.DeepEqual(after.Labels, []string{"changed"}) {
+		t.Error("snapshot should not see later registrations")
+	}
+
+	count := 0
+	previous := ""
+	for entry := range snapshot.All() {
+		if entry.QualifiedName() < previous {
+			t.Errorf("entries not ordered: %q after %q", entry.QualifiedName(), previous)
+		}
+		previous = entry.QualifiedName()
+		count++
+	}
+	if count != snapshot.Len() {
+		t.Errorf("All yielded %d entries, Len is %d", count, snapshot.Len())
+	}
+
+	for range Registered() {
+		break
+	}
+}
+
+func TestRegistryConcurrentAccess(t *testing.T) {
+	type Lane int
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			Register[Lane]("a", "b")
+		}()
+		go func() {
+			defer wg.Done()
+			for entry := range Registered() {
+				_ = entry.QualifiedName()
+			}
+			_, _ = LookupRegistered("github.com/gmllt/enum.Lane")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestScopedRegistry(t *testing.T) {
+	type Tier int
+	key := reflect.TypeFor[Tier]()
+	scoped := NewRegistry()
+
+	if err := scoped.Register(NewEnum[Tier]("free", "pro"), ConflictError); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, ok := DefaultRegistry().Lookup(key); ok {
+		t.Error("scoped registration should not leak into the default registry")
+	}
+
+	entry, ok := scoped.Lookup(key)
+	if !ok || !reflect.DeepEqual(entry.Labels, []string{"free", "pro"}) {
+		t.Errorf("unexpected scoped entry %+v (ok %v)", entry, ok)
+	}
+	if _, ok := scoped.LookupName(entry.QualifiedName()); !ok {
+		t.Error("expected LookupName to find the scoped entry")
+	}
+
+	if err := scoped.Register(NewFlags[Tier]("a", "b", "c"), ConflictError); !errors.Is(err, &ErrRegistryConflict{}) {
+		t.Errorf("expected ErrRegistryConflict, got %v", err)
+	}
+
+	// Zero-value wrappers resolve from the registry they carry
+	w := Wrapper[Tier]{}.WithRegistry(scoped)
+	if err := json.Unmarshal([]byte(`"pro"`), &w); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if w.Get() != 1 {
+		t.Errorf("expected 1, got %d", w.Get())
+	}
+
+	// Options and the registry can be set in any order
+	for name, w := range map[string]Wrapper[Tier]{
+		"registry first": Wrapper[Tier]{}.WithRegistry(scoped).WithRepresentation(RepresentNumber),
+		"registry last":  Wrapper[Tier]{}.WithRepresentation(RepresentNumber).WithRegistry(scoped),
+	} {
+		if err := json.Unmarshal([]byte(`1`), &w); err != nil || w.Get() != 1 {
+			t.Errorf("%s: expected 1, got %d (err %v)", name, w.Get(), err)
+		}
+	}
+
+	// An enum resolved from the default registry is resolved again from scoped
+	Register[Tier]("basic", "premium", "enterprise")
+	defer DefaultRegistry().Unregister(key)
+	w = Wrapper[Tier]{}.WithRepresentation(RepresentNumber)
+	if err := json.Unmarshal([]byte(`2`), &w); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	w = w.WithRegistry(scoped)
+	if err := json.Unmarshal([]byte(`2`), &w); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue from the scoped enum, got %v", err)
+	}
+	w.Set(1)
+	if data, err := json.Marshal(w); err != nil || string(data) != `1` {
+		t.Errorf("expected the representation to be kept, got %s (err %v)", data, err)
+	}
+
+	if !scoped.Unregister(key) {
+		t.Error("expected Unregister to report an existing entry")
+	}
+	if scoped.Unregister(key) {
+		t.Error("expected second Unregister to report no entry")
+	}
+	if scoped.Snapshot().Len() != 0 {
+		t.Errorf("expected empty registry, got %d entries", scoped.Snapshot().Len())
+	}
+}
+
+func TestRegistryZeroValue(t *testing.T) {
+	type Tier int
+	var r Registry
+	if _, ok := r.Lookup(reflect.TypeFor[Tier]()); ok {
+		t.Error("expected an empty registry")
+	}
+	if err := r.Register(NewEnum[Tier]("free", "pro"), ConflictError); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if entry, ok := r.Lookup(reflect.TypeFor[Tier]()); !ok || !reflect.DeepEqual(entry.Labels, []string{"free", "pro"}) {
+		t.Errorf("expected [free pro], got %v (found %v)", entry.Labels, ok)
+	}
+}
+
+func TestRegistryContext(t *testing.T) {
+	type Plan int
+	scoped := NewRegistry()
+	if err := scoped.Register(NewEnum[Plan]("monthly", "yearly"), ConflictError); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	if RegistryFromContext(context.Background()) != DefaultRegistry() {
+		t.Error("expected default registry without a registry in the context")
+	}
+	ctx := ContextWithRegistry(context.Background(), scoped)
+	if RegistryFromContext(ctx) != scoped {
+		t.Error("expected the registry carried by the context")
+	}
+
+	w := Wrapper[Plan]{}.WithContext(ctx)
+	if err := w.UnmarshalText([]byte("yearly")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if w.Get() != 1 {
+		t.Errorf("expected 1, got %d", w.Get())
+	}
+}
+
+func TestRegistrySeal(t *testing.T) {
+	type Phase int
+	r := NewRegistry()
+	if err := r.Register(NewEnum[Phase]("alpha", "beta"), ConflictError); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	r.Seal()
+	if !r.Sealed() {
+		t.Fatal("expected registry to be sealed")
+	}
+
+	// The same definition is still accepted
+	if err := r.Register(NewEnum[Phase]("alpha", "beta"), ConflictError); err != nil {
+		t.Errorf("expected identical definition to be accepted, got %v", err)
+	}
+
+	if err := r.Register(NewEnum[Phase]("gamma"), ConflictOverride); !errors.Is(err, &ErrRegistrySealed{}) {
+		t.Errorf("expected ErrRegistrySealed, got %v", err)
+	}
+	type Other int
+	if err := r.Register(NewEnum[Other]("x"), ConflictError); !errors.Is(err, &ErrRegistrySealed{}) {
+		t.Errorf("expected ErrRegistrySealed for a new type, got %v", err)
+	}
+	func() {
+		defer func() {
+			if err, _ := recover().(error); !errors.Is(err, &ErrRegistrySealed{}) {
+				t.Errorf("expected panic with ErrRegistrySealed, got %v", err)
+			}
+		}()
+		_ = r.Register(NewEnum[Phase]("gamma"), ConflictPanic)
+	}()
+
+	if r.Unregister(reflect.TypeFor[Phase]()) {
+		t.Error("expected Unregister to do nothing on a sealed registry")
+	}
+	if entry, _ := r.Lookup(reflect.TypeFor[Phase]()); !reflect.DeepEqual(entry.Labels, []string{"alpha", "beta"}) {
+		t.Errorf("expected sealed registration to be kept, got %v", entry.Labels)
+	}
+}
+
+func TestWrapperConstructorsSealedRegistry(t *testing.T) {
+	saved := defaultRegistry
+	defaultRegistry = NewRegistry()
+	defer func() { defaultRegistry = saved }()
+
+	type Phase int
+	type Mode string
+	type Perm uint8
+	NewWrapper[Phase]("alpha", "beta")
+	NewStringWrapper[Mode]("on", "off")
+	NewFlagsWrapper[Perm]("read", "write")
+	Seal()
+
+	// The same definitions are still accepted
+	NewWrapperFromMembers(Member[Phase]{Label: "alpha", Value: 0}, Member[Phase]{Label: "beta", Value: 1})
+	NewStringWrapper[Mode]("on", "off")
+	NewFlagsWrapper[Perm]("read", "write")
+
+	tests := map[string]func(){
+		"NewWrapper":            func() { NewWrapper[Phase]("gamma") },
+		"NewWrapperFromMembers": func() { NewWrapperFromMembers(Member[Phase]{Label: "gamma", Value: 2}) },
+		"NewStringWrapper":      func() { NewStringWrapper[Mode]("auto") },
+		"NewFlagsWrapper":       func() { NewFlagsWrapper[Perm]("exec") },
+	}
+	for name, construct := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, &ErrRegistrySealed{}) {
+					t.Errorf("expected panic with ErrRegistrySealed, got %v", err)
+				}
+			}()
+			construct()
+		})
+	}
+}
+
+func TestRegistryVerify(t *testing.T) {
+	type Shape int
+	r := NewRegistry()
+	if err := r.Verify(); err != nil {
+		t.Fatalf("expected no divergence, got %v", err)
+	}
+
+	// Divergent registrations are reported even when they were accepted
+	_ = r.Register(NewEnum[Shape]("circle", "square"), ConflictOverride)
+	_ = r.Register(NewEnum[Shape]("circle", "square"), ConflictOverride)
+	_ = r.Register(NewEnum[Shape]("circle", "triangle"), ConflictOverride)
+	_ = r.Register(NewEnum[Shape]("circle", "triangle"), ConflictOverride)
+
+	err := r.Verify()
+	var conflict *ErrRegistryConflict
+	if !errors.As(err, &conflict) {
+		t.Fatalf("expected ErrRegistryConflict, got %v", err)
+	}
+	if !reflect.DeepEqual(conflict.Existing, []string{"circle", "square"}) ||
+		!reflect.DeepEqual(conflict.Labels, []string{"circle", "triangle"}) {
+		t.Errorf("unexpected divergence %v", conflict)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 1 {
+		t.Errorf("expected 1 divergence, got %d", n)
+	}
+}
@@ -0,0 +1,20 @@
+package enum
+
+import "github.com/gmllt/enum/internal"
+
+// Representation selects how a Wrapper writes and reads its value in JSON and SQL.
+// The other formats always use labels.
+type Representation = internal.Representation
+
+const (
+	// RepresentLabel reads and writes labels, such as "active". This is the default.
+	RepresentLabel = internal.RepresentLabel
+
+	// RepresentNumber reads and writes numeric values, such as 1.
+	// Numbers are validated against the members of the enum.
+	RepresentNumber = internal.RepresentNumber
+
+	// RepresentLabelOrNumber reads labels or numeric values and writes labels.
+	// It eases migrations from numeric to label storage.
+	RepresentLabelOrNumber = internal.RepresentLabelOrNumber
+)
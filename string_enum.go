@@ -0,0 +1,127 @@
+package enum
+
+import (
+	"fmt"
+
+	"github.com/gmllt/enum/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// StringValue is a type constraint for string values used in the StringEnum type.
+type StringValue interface {
+	~string
+}
+
+// StringEnum is a generic enumeration type for string-based values.
+// Each value is its own label, so no separate label table is needed.
+type StringEnum[T StringValue] struct {
+	labels      []string
+	allVals     []T
+	table       *internal.Table[T]
+	yamlStyle   yaml.Style
+	yamlComment bool
+}
+
+// NewStringEnum creates a new StringEnum instance with the provided values.
+func NewStringEnum[T StringValue](values ...T) *StringEnum[T] {
+	labels := make([]string, len(values))
+	allVals := make([]T, len(values))
+	for i, v := range values {
+		labels[i] = string(v)
+		allVals[i] = v
+	}
+
+	return &StringEnum[T]{
+		labels:  labels,
+		allVals: allVals,
+		table:   internal.NewTable(labels, allVals),
+	}
+}
+
+// String returns the string representation of the enumeration value.
+func (e *StringEnum[T]) String(v T) string {
+	if label, ok := e.table.Label(v); ok {
+		return label
+	}
+	return fmt.Sprintf("Invalid(%s)", string(v))
+}
+
+// FromString converts a string to the corresponding enumeration value.
+// The string is matched according to the parse mode of the enum.
+func (e *StringEnum[T]) FromString(s string) (T, error) {
+	if val, ok := e.lookup(s); ok {
+		return val, nil
+	}
+	var zero T
+	return zero, fmt.Errorf("invalid value: %s", s)
+}
+
+// All returns all values of the enum.
+func (e *StringEnum[T]) All() []T {
+	res := make([]T, len(e.allVals))
+	copy(res, e.allVals)
+	return res
+}
+
+// Labels returns all labels of the enum.
+// Note: The returned slice is a copy to prevent modification of internal state.
+// For read-only access, consider using LabelsReadOnly() for better performance.
+func (e *StringEnum[T]) Labels() []string {
+	cp := make([]string, len(e.labels))
+	copy(cp, e.labels)
+	return cp
+}
+
+// LabelsReadOnly returns a read-only view of all labels.
+// WARNING: Do not modify the returned slice as it shares memory with the enum.
+func (e *StringEnum[T]) LabelsReadOnly() []string {
+	return e.labels
+}
+
+// Contains reports whether v is a member of the enum.
+func (e *StringEnum[T]) Contains(v T) bool {
+	return e.table.Contains(v)
+}
+
+// WithParseMode returns a copy of the enum that matches input labels under mode
+// in FromString and every unmarshaller. When several labels are equivalent
+// under mode, the first one wins.
+func (e *StringEnum[T]) WithParseMode(mode ParseMode) *StringEnum[T] {
+	cp := *e
+	cp.table = e.table.WithMode(mode)
+	return &cp
+}
+
+// ParseMode returns the parse mode of the enum.
+func (e *StringEnum[T]) ParseMode() ParseMode {
+	return e.table.Mode()
+}
+
+// WithYAMLStyle returns a copy of the enum whose wrappers write YAML values with style.
+func (e *StringEnum[T]) WithYAMLStyle(style yaml.Style) *StringEnum[T] {
+	cp := *e
+	cp.yamlStyle = style
+	return &cp
+}
+
+// WithYAMLComment returns a copy of the enum whose wrappers write a line
+// comment listing the valid values next to each YAML value.
+func (e *StringEnum[T]) WithYAMLComment(comment bool) *StringEnum[T] {
+	cp := *e
+	cp.yamlComment = comment
+	return &cp
+}
+
+// WithLenientMarshal returns a copy of the enum that writes "Invalid" for a
+// value that is not a member instead of returning *ErrValueOutOfRange.
+// See Enum.WithLenientMarshal.
+func (e *StringEnum[T]) WithLenientMarshal(lenient bool) *StringEnum[T] {
+	cp := *e
+	cp.table = e.table.WithLenientMarshal(lenient)
+	return &cp
+}
+
+// lookup returns the value of a label according to the parse mode.
+func (e *StringEnum[T]) lookup(s string) (T, bool) {
+	return e.table.Lookup(s)
+}
@@ -0,0 +1,101 @@
+package enum
+
+import (
+	"reflect"
+	"testing"
+)
+
+type Region string
+
+// TestNewStringEnum tests the creation of string enums
+func TestNewStringEnum(t *testing.T) {
+	regions := NewStringEnum[Region]("eu-west-1", "us-east-1", "ap-south-1")
+
+	if len(regions.labels) != 3 || len(regions.allVals) != 3 {
+		t.Fatalf("unexpected internal sizes: %d labels, %d values",
+			len(regions.labels), len(regions.allVals))
+	}
+
+	expectedLabels := []string{"eu-west-1", "us-east-1", "ap-south-1"}
+	if !reflect.DeepEqual(regions.Labels(), expectedLabels) {
+		t.Errorf("expected labels %v, got %v", expectedLabels, regions.Labels())
+	}
+
+	expectedVals := []Region{"eu-west-1", "us-east-1", "ap-south-1"}
+	if !reflect.DeepEqual(regions.All(), expectedVals) {
+		t.Errorf("expected values %v, got %v", expectedVals, regions.All())
+	}
+}
+
+// TestStringEnumString tests the string representation of string enum values
+func TestStringEnumString(t *testing.T) {
+	regions := NewStringEnum[Region]("eu-west-1", "us-east-1")
+
+	tests := []struct {
+		name     string
+		value    Region
+		expected string
+	}{
+		{
+			name:     "valid value",
+			value:    "us-east-1",
+			expected: "us-east-1",
+		},
+		{
+			name:     "unknown value",
+			value:    "mars-1",
+			expected: "Invalid(mars-1)",
+		},
+		{
+			name:     "empty value",
+			value:    "",
+			expected: "Invalid()",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := regions.String(tt.value); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+// TestStringEnumFromString tests string to value conversion
+func TestStringEnumFromString(t *testing.T) {
+	regions := NewStringEnum[Region]("eu-west-1", "us-east-1")
+
+	val, err := regions.FromString("eu-west-1")
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if val != "eu-west-1" {
+		t.Errorf("expected %q, got %q", "eu-west-1", val)
+	}
+
+	if _, err := regions.FromString("EU-WEST-1"); err == nil {
+		t.Error("expected error for unknown value")
+	}
+
+	if !regions.Contains("us-east-1") || regions.Contains("mars-1") {
+		t.Error("Contains should only report declared values")
+	}
+}
+
+// TestStringEnumImmutability tests that returned slices are copies
+func TestStringEnumImmutability(t *testing.T) {
+	regions := NewStringEnum[Region]("eu-west-1", "us-east-1")
+
+	all := regions.All()
+	all[0] = "modified"
+	if regions.All()[0] != "eu-west-1" {
+		t.Error("modifying All() result affected the enum internal state")
+	}
+
+	labels := regions.Labels()
+	labels[0] = "modified"
+	if regions.Labels()[0] != "eu-west-1" {
+		t.Error("modifying Labels() result affected the enum internal state")
+	}
+}
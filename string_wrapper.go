@@ -0,0 +1,302 @@
+package enum
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"slices"
+
+	"github.com/gmllt/enum/internal"
+	"gopkg.in/yaml.v3"
+)
+
+// StringWrapper wraps a StringEnum and provides JSON/YAML serialization.
+type StringWrapper[T StringValue] struct {
+	Enum    *StringEnum[T]
+	Current T
+	labels  []string
+	// options holds the With* options of a wrapper without an enum, applied
+	// once ensureEnum resolves it.
+	options []func(*StringEnum[T]) *StringEnum[T]
+}
+
+// Ensure StringWrapper implements the necessary interfaces.
+var (
+	_ json.Marshaler             = (*StringWrapper[string])(nil)
+	_ json.Unmarshaler           = (*StringWrapper[string])(nil)
+	_ yaml.Marshaler             = (*StringWrapper[string])(nil)
+	_ yaml.Unmarshaler           = (*StringWrapper[string])(nil)
+	_ encoding.TextMarshaler     = (*StringWrapper[string])(nil)
+	_ encoding.TextUnmarshaler   = (*StringWrapper[string])(nil)
+	_ encoding.BinaryMarshaler   = (*StringWrapper[string])(nil)
+	_ encoding.BinaryUnmarshaler = (*StringWrapper[string])(nil)
+	_ xml.Marshaler              = (*StringWrapper[string])(nil)
+	_ xml.Unmarshaler            = (*StringWrapper[string])(nil)
+	_ xml.MarshalerAttr          = (*StringWrapper[string])(nil)
+	_ xml.UnmarshalerAttr        = (*StringWrapper[string])(nil)
+	_ driver.Valuer              = (*StringWrapper[string])(nil)
+	_ sql.Scanner                = (*StringWrapper[string])(nil)
+)
+
+// NewStringWrapper creates a new StringWrapper with the given values and
+// registers its enum for T. It panics like NewWrapper if the default registry
+// is sealed.
+func NewStringWrapper[T StringValue](values ...T) StringWrapper[T] {
+	e := NewStringEnum(values...)
+	if err := defaultRegistry.Register(e, ConflictOverride); err != nil {
+		panic(err)
+	}
+	return StringWrapper[T]{
+		Enum:   e,
+		labels: e.labels,
+	}
+}
+
+// String returns the string representation of the wrapped value.
+func (w StringWrapper[T]) String() string {
+	if err := w.ensureEnum(); err != nil {
+		return fmt.Sprintf("Invalid(%s)", string(w.Current))
+	}
+	return w.Enum.String(w.Current)
+}
+
+// All returns all values of the wrapped enum, or nil if it is not configured.
+func (w StringWrapper[T]) All() []T {
+	if err := w.ensureEnum(); err != nil {
+		return nil
+	}
+	return w.Enum.All()
+}
+
+// Labels returns all labels of the wrapped enum, or nil if it is not configured.
+func (w StringWrapper[T]) Labels() []string {
+	if err := w.ensureEnum(); err != nil {
+		return nil
+	}
+	return w.Enum.Labels()
+}
+
+// WithParseMode returns a copy of the wrapper whose enum matches input labels under mode.
+// On a zero-value wrapper, mode applies to the enum registered for T.
+func (w StringWrapper[T]) WithParseMode(mode ParseMode) StringWrapper[T] {
+	return w.with(func(e *StringEnum[T]) *StringEnum[T] { return e.WithParseMode(mode) })
+}
+
+// WithYAMLStyle returns a copy of the wrapper that writes YAML values with style.
+func (w StringWrapper[T]) WithYAMLStyle(style yaml.Style) StringWrapper[T] {
+	return w.with(func(e *StringEnum[T]) *StringEnum[T] { return e.WithYAMLStyle(style) })
+}
+
+// WithYAMLComment returns a copy of the wrapper that writes a line comment
+// listing the valid values next to its YAML value.
+func (w StringWrapper[T]) WithYAMLComment(comment bool) StringWrapper[T] {
+	return w.with(func(e *StringEnum[T]) *StringEnum[T] { return e.WithYAMLComment(comment) })
+}
+
+// WithLenientMarshal returns a copy of the wrapper that writes "Invalid" for
+// a value that is not a member. See Enum.WithLenientMarshal.
+func (w StringWrapper[T]) WithLenientMarshal(lenient bool) StringWrapper[T] {
+	return w.with(func(e *StringEnum[T]) *StringEnum[T] { return e.WithLenientMarshal(lenient) })
+}
+
+// with applies option to the enum of the wrapper. A zero-value wrapper keeps
+// it until ensureEnum resolves its enum, so that no option is lost when T is
+// not registered yet.
+func (w StringWrapper[T]) with(option func(*StringEnum[T]) *StringEnum[T]) StringWrapper[T] {
+	if w.Enum != nil {
+		w.Enum = option(w.Enum)
+		return w
+	}
+	w.options = append(slices.Clip(w.options), option)
+	return w
+}
+
+// ensureEnum initializes the Enum if it is nil, from the wrapper labels or
+// else from the default registry. It returns an *ErrEnumNotConfigured if
+// neither is available.
+func (w *StringWrapper[T]) ensureEnum() error {
+	if w.Enum != nil {
+		return nil
+	}
+	if w.labels != nil {
+		w.Enum = newStringEnumFromLabels[T](w.labels)
+	} else if r, ok := lookupRegistration[T](defaultRegistry); ok {
+		if e, ok := r.enum.(*StringEnum[T]); ok {
+			w.Enum = e
+		} else {
+			w.Enum = newStringEnumFromLabels[T](r.entry.Labels)
+		}
+		w.labels = w.Enum.labels
+	} else {
+		return NewEnumNotConfiguredError(typeKey[T]().String())
+	}
+	for _, option := range w.options {
+		w.Enum = option(w.Enum)
+	}
+	return nil
+}
+
+// newStringEnumFromLabels creates a StringEnum from registered labels.
+func newStringEnumFromLabels[T StringValue](labels []string) *StringEnum[T] {
+	values := make([]T, len(labels))
+	for i, label := range labels {
+		values[i] = T(label)
+	}
+	return NewStringEnum(values...)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (w StringWrapper[T]) MarshalJSON() ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.ToJSON[T](w.Enum.table, w.Current)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (w *StringWrapper[T]) UnmarshalJSON(data []byte) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FromJSON[T](w.Enum.table, data)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler. It returns a scalar node styled
+// and commented according to the enum.
+func (w StringWrapper[T]) MarshalYAML() (any, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	node, err := internal.ToYAMLNode[T](w.Enum.table, w.Current, w.Enum.yamlStyle, w.Enum.yamlComment)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. An unknown value returns an
+// *ErrInvalidEnumValue holding its line and column.
+func (w *StringWrapper[T]) UnmarshalYAML(node *yaml.Node) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FromYAMLNode[T](w.Enum.table, node)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
+
+// Get returns the current value.
+func (w StringWrapper[T]) Get() T {
+	return w.Current
+}
+
+// Set sets the current value.
+func (w *StringWrapper[T]) Set(v T) {
+	w.Current = v
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (w StringWrapper[T]) MarshalText() ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.ToText[T](w.Enum.table, w.Current)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (w *StringWrapper[T]) UnmarshalText(text []byte) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FromText[T](w.Enum.table, text)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (w StringWrapper[T]) MarshalBinary() ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.ToBinary[T](w.Enum.table, w.Current)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (w *StringWrapper[T]) UnmarshalBinary(data []byte) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FromBinary[T](w.Enum.table, data)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler. The element holds the text form of the value.
+func (w StringWrapper[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text, err := w.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (w *StringWrapper[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+	return w.UnmarshalText([]byte(text))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (w StringWrapper[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, err := w.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (w *StringWrapper[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return w.UnmarshalText([]byte(attr.Value))
+}
+
+// Value implements driver.Valuer for SQL integration.
+func (w StringWrapper[T]) Value() (driver.Value, error) {
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	return internal.ToSQLValue[T](w.Enum.table, w.Current)
+}
+
+// Scan implements sql.Scanner for SQL integration.
+func (w *StringWrapper[T]) Scan(src any) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FromSQLValue[T](w.Enum.table, src)
+	if err != nil {
+		return err
+	}
+	w.Current = val
+	return nil
+}
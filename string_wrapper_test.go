@@ -0,0 +1,144 @@
+package enum
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+// Custom types for string wrapper testing to avoid registry conflicts
+type (
+	StringWrapperTestType1 string
+	StringWrapperTestType2 string
+)
+
+// TestStringWrapperMarshalling tests all marshalling formats of StringWrapper
+func TestStringWrapperMarshalling(t *testing.T) {
+	wrapper := NewStringWrapper[Region]("eu-west-1", "us-east-1", "ap-south-1")
+	wrapper.Set("us-east-1")
+
+	jsonData, err := json.Marshal(wrapper)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	if string(jsonData) != `"us-east-1"` {
+		t.Errorf("expected %q, got %s", `"us-east-1"`, jsonData)
+	}
+
+	yamlValue, err := wrapper.MarshalYAML()
+	if err != nil {
+		t.Fatalf("MarshalYAML failed: %v", err)
+	}
+	if yamlNodeValue(yamlValue) != "us-east-1" {
+		t.Errorf("expected %q, got %v", "us-east-1", yamlValue)
+	}
+
+	text, err := wrapper.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText failed: %v", err)
+	}
+	if string(text) != "us-east-1" {
+		t.Errorf("expected %q, got %q", "us-east-1", text)
+	}
+
+	binaryData, err := wrapper.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+
+	sqlValue, err := wrapper.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	if sqlValue != "us-east-1" {
+		t.Errorf("expected %q, got %v", "us-east-1", sqlValue)
+	}
+
+	decoded := NewStringWrapper[Region]("eu-west-1", "us-east-1", "ap-south-1")
+	if err := decoded.UnmarshalBinary(binaryData); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if decoded.Get() != "us-east-1" {
+		t.Errorf("expected %q, got %q", "us-east-1", decoded.Get())
+	}
+
+	if err := decoded.UnmarshalJSON([]byte(`"ap-south-1"`)); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if decoded.Get() != "ap-south-1" {
+		t.Errorf("expected %q, got %q", "ap-south-1", decoded.Get())
+	}
+
+	if err := decoded.UnmarshalText([]byte("eu-west-1")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if decoded.Get() != "eu-west-1" {
+		t.Errorf("expected %q, got %q", "eu-west-1", decoded.Get())
+	}
+
+	if err := decoded.Scan([]byte("us-east-1")); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if decoded.Get() != "us-east-1" {
+		t.Errorf("expected %q, got %q", "us-east-1", decoded.Get())
+	}
+
+	xmlData, err := xml.Marshal(struct {
+		XMLName xml.Name              `xml:"host"`
+		Region  StringWrapper[Region] `xml:"region,attr"`
+	}{Region: wrapper})
+	if err != nil || string(xmlData) != `<host region="us-east-1"></host>` {
+		t.Errorf("unexpected XML %s (err %v)", xmlData, err)
+	}
+	if err := decoded.UnmarshalXMLAttr(xml.Attr{Value: "ap-south-1"}); err != nil || decoded.Get() != "ap-south-1" {
+		t.Errorf("expected %q, got %q (err %v)", "ap-south-1", decoded.Get(), err)
+	}
+}
+
+// TestStringWrapperInvalidValues tests that invalid values return structured errors
+func TestStringWrapperInvalidValues(t *testing.T) {
+	wrapper := NewStringWrapper[Region]("eu-west-1", "us-east-1")
+
+	err := wrapper.UnmarshalJSON([]byte(`"mars-1"`))
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) {
+		t.Fatalf("expected ErrInvalidEnumValue, got %v", err)
+	}
+	if invalidErr.Value != "mars-1" {
+		t.Errorf("expected invalid value %q, got %q", "mars-1", invalidErr.Value)
+	}
+
+	if err := wrapper.UnmarshalBinary([]byte{0}); !errors.As(err, new(*ErrBinaryDataTooShort)) {
+		t.Errorf("expected ErrBinaryDataTooShort, got %v", err)
+	}
+
+	wrapper.Set("mars-1")
+	if _, err := wrapper.Value(); err == nil {
+		t.Error("expected error for unknown value")
+	}
+	if wrapper.String() != "Invalid(mars-1)" {
+		t.Errorf("expected %q, got %q", "Invalid(mars-1)", wrapper.String())
+	}
+}
+
+// TestStringWrapperEnsureEnum tests lazy initialization from local labels and the registry
+func TestStringWrapperEnsureEnum(t *testing.T) {
+	local := StringWrapper[StringWrapperTestType1]{labels: []string{"draft", "published"}}
+	if err := local.UnmarshalText([]byte("published")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if local.Get() != "published" {
+		t.Errorf("expected %q, got %q", "published", local.Get())
+	}
+
+	_ = NewStringWrapper[StringWrapperTestType2]("small", "large")
+
+	var registered StringWrapper[StringWrapperTestType2]
+	if err := json.Unmarshal([]byte(`"large"`), &registered); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if registered.Get() != "large" {
+		t.Errorf("expected %q, got %q", "large", registered.Get())
+	}
+}
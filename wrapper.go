@@ -1,12 +1,22 @@
 package enum
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"encoding"
+	"encoding/gob"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
 
 	"github.com/gmllt/enum/internal"
+	"gopkg.in/yaml.v3"
 )
 
 // Wrapper wraps an Enum and provides JSON/YAML serialization.
@@ -14,6 +24,23 @@ type Wrapper[T Value] struct {
 	Enum    *Enum[T]
 	Current T
 	labels  []string
+	unknown *unknownValue
+	// registry resolves the enum of a zero-value wrapper, the default registry if nil.
+	registry *Registry
+	// registered is true when Enum was resolved from registry.
+	registered bool
+	// options holds the With* options of a wrapper without an enum or with a
+	// registered one, applied whenever ensureEnum resolves it.
+	options []func(*Enum[T]) *Enum[T]
+}
+
+// unknownValue is an input that did not match any label, kept verbatim
+// when the enum preserves unknown values.
+type unknownValue struct {
+	raw     string
+	numeric bool
+	// binary holds the original data when it came from UnmarshalBinary.
+	binary []byte
 }
 
 // Ensure Wrapper implements the necessary interfaces.
@@ -24,77 +51,279 @@ var (
 	_ encoding.TextUnmarshaler   = (*Wrapper[int])(nil)
 	_ encoding.BinaryMarshaler   = (*Wrapper[int])(nil)
 	_ encoding.BinaryUnmarshaler = (*Wrapper[int])(nil)
+	_ yaml.Marshaler             = (*Wrapper[int])(nil)
+	_ yaml.Unmarshaler           = (*Wrapper[int])(nil)
+	_ encoding.TextAppender      = (*Wrapper[int])(nil)
+	_ encoding.BinaryAppender    = (*Wrapper[int])(nil)
+	_ gob.GobEncoder             = (*Wrapper[int])(nil)
+	_ gob.GobDecoder             = (*Wrapper[int])(nil)
+	_ xml.Marshaler              = (*Wrapper[int])(nil)
+	_ xml.Unmarshaler            = (*Wrapper[int])(nil)
+	_ xml.MarshalerAttr          = (*Wrapper[int])(nil)
+	_ xml.UnmarshalerAttr        = (*Wrapper[int])(nil)
 	_ driver.Valuer              = (*Wrapper[int])(nil)
 	_ sql.Scanner                = (*Wrapper[int])(nil)
 )
 
-// NewWrapper creates a new Wrapper with the given labels.
+// NewWrapper creates a new Wrapper with the given labels and registers
+// its enum for T, replacing any previous registration. Like Register, it
+// panics with an *ErrRegistrySealed if the default registry is sealed and
+// the enum differs from the registered one.
 func NewWrapper[T Value](labels ...string) Wrapper[T] {
-	Register[T](labels...)
 	e := NewEnum[T](labels...)
+	if err := RegisterEnum(e, ConflictOverride); err != nil {
+		panic(err)
+	}
 	return Wrapper[T]{
 		Enum:   e,
 		labels: labels,
 	}
 }
 
+// NewWrapperFromMembers creates a new Wrapper for an enum with explicit values
+// and registers its enum for T, replacing any previous registration. It
+// panics like NewWrapper if the default registry is sealed.
+func NewWrapperFromMembers[T Value](members ...Member[T]) Wrapper[T] {
+	e := NewEnumFromMembers(members...)
+	if err := RegisterEnum(e, ConflictOverride); err != nil {
+		panic(err)
+	}
+	return Wrapper[T]{
+		Enum:   e,
+		labels: e.labels,
+	}
+}
+
 // String returns the string representation of the wrapped value.
+// For a preserved unknown value, it returns the original input.
+// Zero-value wrappers use the enum registered for T, and return
+// "Invalid(v)" if there is none.
 func (w Wrapper[T]) String() string {
+	if w.unknown != nil {
+		return w.unknown.raw
+	}
+	if err := w.ensureEnum(); err != nil {
+		return fmt.Sprintf("Invalid(%d)", w.Current)
+	}
 	return w.Enum.String(w.Current)
 }
 
-// All returns all values of the wrapped enum.
+// IsUnknown reports whether the wrapper holds a preserved unknown value.
+func (w Wrapper[T]) IsUnknown() bool {
+	return w.unknown != nil
+}
+
+// Unknown returns the preserved unknown input and whether there is one.
+func (w Wrapper[T]) Unknown() (string, bool) {
+	if w.unknown == nil {
+		return "", false
+	}
+	return w.unknown.raw, true
+}
+
+// All returns all values of the wrapped enum, or nil if it is not configured.
 func (w Wrapper[T]) All() []T {
+	if err := w.ensureEnum(); err != nil {
+		return nil
+	}
 	return w.Enum.All()
 }
 
-// Labels returns all labels of the wrapped enum.
+// Labels returns all labels of the wrapped enum, or nil if it is not configured.
 func (w Wrapper[T]) Labels() []string {
+	if err := w.ensureEnum(); err != nil {
+		return nil
+	}
 	return w.Enum.Labels()
 }
 
-// ensureEnum initializes the Enum if it is nil and labels are provided.
-func (w *Wrapper[T]) ensureEnum() {
-	if w.Enum == nil {
-		if w.labels != nil {
-			w.Enum = NewEnum[T](w.labels...)
-		} else if labels := GetLabels[T](); labels != nil {
-			w.Enum = NewEnum[T](labels...)
-			w.labels = labels
+// WithParseMode returns a copy of the wrapper whose enum matches input labels under mode.
+// On a zero-value wrapper, mode applies to the enum registered for T.
+func (w Wrapper[T]) WithParseMode(mode ParseMode) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithParseMode(mode) })
+}
+
+// WithAliases returns a copy of the wrapper whose enum also accepts the given aliases.
+func (w Wrapper[T]) WithAliases(aliases ...Alias[T]) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithAliases(aliases...) })
+}
+
+// WithDeprecationHook returns a copy of the wrapper whose enum calls hook
+// whenever a deprecated alias is parsed.
+func (w Wrapper[T]) WithDeprecationHook(hook func(alias, label string)) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithDeprecationHook(hook) })
+}
+
+// WithRepresentation returns a copy of the wrapper that uses r for JSON and SQL.
+func (w Wrapper[T]) WithRepresentation(r Representation) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithRepresentation(r) })
+}
+
+// WithBinaryEncoding returns a copy of the wrapper that writes binary data with enc.
+func (w Wrapper[T]) WithBinaryEncoding(enc BinaryEncoding) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithBinaryEncoding(enc) })
+}
+
+// WithPreserveUnknown returns a copy of the wrapper that keeps unknown labels
+// instead of failing to unmarshal them. See Enum.WithPreserveUnknown.
+func (w Wrapper[T]) WithPreserveUnknown(preserve bool) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithPreserveUnknown(preserve) })
+}
+
+// WithYAMLStyle returns a copy of the wrapper that writes YAML labels with style.
+func (w Wrapper[T]) WithYAMLStyle(style yaml.Style) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithYAMLStyle(style) })
+}
+
+// WithYAMLComment returns a copy of the wrapper that writes a line comment
+// listing the valid labels next to its YAML value.
+func (w Wrapper[T]) WithYAMLComment(comment bool) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithYAMLComment(comment) })
+}
+
+// WithLenientMarshal returns a copy of the wrapper that writes "Invalid" for
+// a value that is not a member. See Enum.WithLenientMarshal.
+func (w Wrapper[T]) WithLenientMarshal(lenient bool) Wrapper[T] {
+	return w.with(func(e *Enum[T]) *Enum[T] { return e.WithLenientMarshal(lenient) })
+}
+
+// WithRegistry returns a copy of the wrapper that resolves its enum from r
+// instead of the default registry when it has none. An enum already resolved
+// from a registry is resolved again from r, with the same options, so the
+// result does not depend on the order of the With* calls.
+func (w Wrapper[T]) WithRegistry(r *Registry) Wrapper[T] {
+	if w.registered {
+		w.Enum, w.labels, w.registered = nil, nil, false
+	}
+	w.registry = r
+	return w
+}
+
+// WithContext returns a copy of the wrapper that resolves its enum from the
+// registry carried by ctx. See ContextWithRegistry.
+func (w Wrapper[T]) WithContext(ctx context.Context) Wrapper[T] {
+	return w.WithRegistry(RegistryFromContext(ctx))
+}
+
+// with applies option to the enum of the wrapper. A zero-value wrapper keeps
+// it until ensureEnum resolves its enum, so that no option is lost when T is
+// not registered yet.
+func (w Wrapper[T]) with(option func(*Enum[T]) *Enum[T]) Wrapper[T] {
+	if w.Enum != nil {
+		w.Enum = option(w.Enum)
+		if !w.registered {
+			return w
 		}
 	}
+	w.options = append(slices.Clip(w.options), option)
+	return w
+}
+
+// ensureEnum initializes the Enum if it is nil, from the wrapper labels or
+// else from the registry of the wrapper. It returns an *ErrEnumNotConfigured
+// if neither is available.
+func (w *Wrapper[T]) ensureEnum() error {
+	if w.Enum != nil {
+		return nil
+	}
+	registry := w.registry
+	if registry == nil {
+		registry = defaultRegistry
+	}
+	if w.labels != nil {
+		w.Enum = NewEnum[T](w.labels...)
+	} else if r, ok := lookupRegistration[T](registry); ok {
+		e, err := registeredEnum[T](r)
+		if err != nil {
+			return err
+		}
+		w.Enum = e
+		w.labels = w.Enum.labels
+		w.registered = true
+	} else {
+		return NewEnumNotConfiguredError(typeKey[T]().String())
+	}
+	for _, option := range w.options {
+		w.Enum = option(w.Enum)
+	}
+	return nil
+}
+
+// registeredEnum returns the enum of a registration, built from its labels
+// for a Register call. It returns an *ErrRegistryKindMismatch for another kind
+// of definition, and an *ErrTooManyLabels if T cannot hold every label.
+func registeredEnum[T Value](r registration) (*Enum[T], error) {
+	switch r.entry.Kind {
+	case KindEnum:
+		return r.enum.(*Enum[T]), nil
+	case KindLabels:
+		if err := internal.ValidateCapacity[T](len(r.entry.Labels)); err != nil {
+			return nil, err
+		}
+		return NewEnum[T](r.entry.Labels...), nil
+	default:
+		return nil, NewRegistryKindMismatchError(typeKey[T]().String(), r.entry.Kind.String(), KindEnum.String())
+	}
 }
 
 // MarshalJSON implements json.Marshaler.
 func (w Wrapper[T]) MarshalJSON() ([]byte, error) {
-	return internal.ToJSON[T](w.Enum.labels, w.Current)
+	return w.AppendJSON(nil)
+}
+
+// AppendJSON appends the JSON form of the wrapped value to b.
+func (w Wrapper[T]) AppendJSON(b []byte) ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return b, err
+	}
+	if w.unknown != nil {
+		if w.unknown.numeric {
+			return append(b, w.unknown.raw...), nil
+		}
+		return internal.AppendJSONString(b, w.unknown.raw), nil
+	}
+	return internal.AppendJSONAs[T](w.Enum.table, b, w.Current, w.Enum.repr)
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
+// UnmarshalJSON implements json.Unmarshaler. JSON null is not a member and
+// returns an *ErrInvalidEnumValue, even when the enum preserves unknown
+// values; use NullWrapper for nullable fields.
 func (w *Wrapper[T]) UnmarshalJSON(data []byte) error {
-	w.ensureEnum()
-	val, err := internal.FromJSON[T](w.Enum.labels, data)
-	if err != nil {
+	if err := w.ensureEnum(); err != nil {
 		return err
 	}
-	w.Current = val
-	return nil
+	if isJSONNull(data) {
+		return NewInvalidEnumValueError("null", w.Enum.Labels())
+	}
+	val, err := internal.FromJSONAs[T](w.Enum.table, data, w.Enum.repr)
+	return w.apply(val, err, true, isJSONNumber(data))
 }
 
-// MarshalYAML implements yaml.Marshaler.
+// MarshalYAML implements yaml.Marshaler. It returns a scalar node styled
+// and commented according to the enum, see Enum.WithYAMLStyle and
+// Enum.WithYAMLComment.
 func (w Wrapper[T]) MarshalYAML() (any, error) {
-	return internal.ToYAML[T](w.Enum.labels, w.Current)
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	if w.unknown != nil {
+		return internal.YAMLScalar(w.unknown.raw, w.Enum.yamlStyle), nil
+	}
+	node, err := internal.ToYAMLNode[T](w.Enum.table, w.Current, w.Enum.yamlStyle, w.Enum.yamlComment)
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (w *Wrapper[T]) UnmarshalYAML(unmarshal func(any) error) error {
-	w.ensureEnum()
-	val, err := internal.FromYAML[T](w.Enum.labels, unmarshal)
-	if err != nil {
+// UnmarshalYAML implements yaml.Unmarshaler. An unknown label returns an
+// *ErrInvalidEnumValue holding its line and column.
+func (w *Wrapper[T]) UnmarshalYAML(node *yaml.Node) error {
+	if err := w.ensureEnum(); err != nil {
 		return err
 	}
-	w.Current = val
-	return nil
+	val, err := internal.FromYAMLNode[T](w.Enum.table, node)
+	return w.apply(val, err, true, false)
 }
 
 // Get returns the current value.
@@ -102,55 +331,209 @@ func (w Wrapper[T]) Get() T {
 	return w.Current
 }
 
-// Set sets the current value.
+// Set sets the current value and clears any preserved unknown value.
 func (w *Wrapper[T]) Set(v T) {
 	w.Current = v
+	w.unknown = nil
 }
 
 // MarshalText implements encoding.TextMarshaler.
 func (w Wrapper[T]) MarshalText() ([]byte, error) {
-	return internal.ToText[T](w.Enum.labels, w.Current)
+	return w.AppendText(nil)
+}
+
+// AppendText implements encoding.TextAppender.
+func (w Wrapper[T]) AppendText(b []byte) ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return b, err
+	}
+	if w.unknown != nil {
+		return append(b, w.unknown.raw...), nil
+	}
+	return internal.AppendText[T](w.Enum.table, b, w.Current)
 }
 
 // UnmarshalText implements encoding.TextUnmarshaler.
 func (w *Wrapper[T]) UnmarshalText(text []byte) error {
-	w.ensureEnum()
-	val, err := internal.FromText[T](w.Enum.labels, text)
-	if err != nil {
+	if err := w.ensureEnum(); err != nil {
 		return err
 	}
-	w.Current = val
-	return nil
+	val, err := internal.FromText[T](w.Enum.table, text)
+	return w.apply(val, err, true, false)
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
 func (w Wrapper[T]) MarshalBinary() ([]byte, error) {
-	return internal.ToBinary[T](w.Enum.labels, w.Current)
+	return w.AppendBinary(nil)
+}
+
+// AppendBinary implements encoding.BinaryAppender.
+func (w Wrapper[T]) AppendBinary(b []byte) ([]byte, error) {
+	if err := w.ensureEnum(); err != nil {
+		return b, err
+	}
+	if w.unknown != nil {
+		if w.unknown.binary != nil {
+			return append(b, w.unknown.binary...), nil
+		}
+		return internal.AppendLabelBinary(b, w.unknown.raw)
+	}
+	return internal.AppendBinaryAs[T](w.Enum.table, b, w.Current, w.Enum.binary, w.Enum.fingerprint)
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler.
 func (w *Wrapper[T]) UnmarshalBinary(data []byte) error {
-	w.ensureEnum()
-	val, err := internal.FromBinary[T](w.Enum.labels, data)
-	if err != nil {
+	if err := w.ensureEnum(); err != nil {
 		return err
 	}
-	w.Current = val
+	val, err := internal.FromBinaryAs[T](w.Enum.table, data, w.Enum.fingerprint)
+	if err := w.apply(val, err, true, false); err != nil {
+		return err
+	}
+	if w.unknown != nil {
+		w.unknown.binary = bytes.Clone(data)
+	}
 	return nil
 }
 
+// ReadBinary reads one binary-encoded value from r, in any binary encoding,
+// without reading past it. It returns io.EOF if r is empty.
+func (w *Wrapper[T]) ReadBinary(r io.Reader) error {
+	frame, err := internal.ReadBinaryFrame(r)
+	if err != nil {
+		return err
+	}
+	return w.UnmarshalBinary(frame)
+}
+
+// GobEncode implements gob.GobEncoder. Only the member is encoded, in the
+// binary encoding of the enum: its label, or its value as a varint with
+// BinaryVarint, instead of the whole enum.
+func (w Wrapper[T]) GobEncode() ([]byte, error) {
+	return w.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder. A zero-value wrapper resolves its
+// enum from the registry, like the other unmarshallers.
+func (w *Wrapper[T]) GobDecode(data []byte) error {
+	return w.UnmarshalBinary(data)
+}
+
+// MarshalXML implements xml.Marshaler. The element holds the text form of the value.
+func (w Wrapper[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	text, err := w.MarshalText()
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(string(text), start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler.
+func (w *Wrapper[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return err
+	}
+	return w.UnmarshalText([]byte(text))
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr.
+func (w Wrapper[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, err := w.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr.
+func (w *Wrapper[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return w.UnmarshalText([]byte(attr.Value))
+}
+
 // Value implements driver.Valuer for SQL integration.
 func (w Wrapper[T]) Value() (driver.Value, error) {
-	return internal.ToSQLValue[T](w.Enum.labels, w.Current)
+	if err := w.ensureEnum(); err != nil {
+		return nil, err
+	}
+	if w.unknown != nil {
+		if w.unknown.numeric {
+			return strconv.ParseInt(w.unknown.raw, 10, 64)
+		}
+		return w.unknown.raw, nil
+	}
+	return internal.ToSQLValueAs[T](w.Enum.table, w.Current, w.Enum.repr)
 }
 
 // Scan implements sql.Scanner for SQL integration.
 func (w *Wrapper[T]) Scan(src any) error {
-	w.ensureEnum()
-	val, err := internal.FromSQLValue[T](w.Enum.labels, src)
-	if err != nil {
+	if err := w.ensureEnum(); err != nil {
 		return err
 	}
+	val, err := internal.FromSQLValueAs[T](w.Enum.table, src, w.Enum.repr)
+	// Only input the representation reads can be preserved: other types
+	// fail with a placeholder instead of their value.
+	_, isInt := src.(int64)
+	numeric := isInt && w.Enum.repr != RepresentLabel
+	_, isString := src.(string)
+	_, isBytes := src.([]byte)
+	return w.apply(val, err, numeric || isString || isBytes, numeric)
+}
+
+// Int64 returns the wrapped value as an int64, for numeric wire formats
+// such as Protocol Buffers. It returns an *ErrValueOutOfRange if the value
+// is not a member or does not fit in an int64. A preserved unknown number
+// is returned unchanged, and a preserved unknown label returns an
+// *ErrInvalidEnumValue.
+func (w Wrapper[T]) Int64() (int64, error) {
+	if err := w.ensureEnum(); err != nil {
+		return 0, err
+	}
+	if w.unknown != nil {
+		if !w.unknown.numeric {
+			return 0, NewInvalidEnumValueError(w.unknown.raw, w.Enum.Labels())
+		}
+		return strconv.ParseInt(w.unknown.raw, 10, 64)
+	}
+	return internal.ToInt64[T](w.Enum.table, w.Current)
+}
+
+// SetInt64 sets the wrapped value from a number of a numeric wire format.
+// A number that is not a member returns an *ErrInvalidEnumValue, or is
+// preserved when the enum preserves unknown values.
+func (w *Wrapper[T]) SetInt64(n int64) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	val, err := internal.FromSQLValueAs[T](w.Enum.table, n, RepresentNumber)
+	return w.apply(val, err, true, true)
+}
+
+// apply stores a decoded value. When the enum preserves unknown values and
+// err only reports an unknown label or number, the raw input is kept instead.
+func (w *Wrapper[T]) apply(val T, err error, preservable, numeric bool) error {
+	if err != nil {
+		var invalidErr *ErrInvalidEnumValue
+		if !preservable || !w.Enum.preserveUnknown || !errors.As(err, &invalidErr) {
+			return err
+		}
+		var zero T
+		w.Current = zero
+		w.unknown = &unknownValue{raw: invalidErr.Value, numeric: numeric}
+		return nil
+	}
 	w.Current = val
+	w.unknown = nil
 	return nil
 }
+
+// isJSONNull reports whether data holds JSON null.
+func isJSONNull(data []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(data), []byte("null"))
+}
+
+// isJSONNumber reports whether data holds a JSON number.
+func isJSONNumber(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && (data[0] == '-' || data[0] >= '0' && data[0] <= '9')
+}
@@ -0,0 +1,56 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+// The go1.27 term is required: encoding/json/v2 is part of the go1.27 API, so
+// without it this file would be checked at the go.mod language version.
+
+package enum
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+
+	"github.com/gmllt/enum/internal"
+)
+
+// Ensure Wrapper implements the encoding/json/v2 interfaces.
+var (
+	_ jsonv2.MarshalerTo     = (*Wrapper[int])(nil)
+	_ jsonv2.UnmarshalerFrom = (*Wrapper[int])(nil)
+)
+
+// MarshalJSONTo implements json.MarshalerTo of encoding/json/v2.
+// The value is appended to the buffer of enc, without allocating.
+func (w Wrapper[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	b, err := w.AppendJSON(enc.AvailableBuffer())
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom of encoding/json/v2.
+// Labels are matched like UnmarshalJSON does, following the parse mode of the
+// enum. An invalid value returns an *ErrInvalidEnumValue holding its JSON
+// pointer.
+func (w *Wrapper[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if err := w.ensureEnum(); err != nil {
+		return err
+	}
+	data, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	if data.Kind() == 'n' {
+		nullErr := NewInvalidEnumValueError("null", w.Enum.Labels())
+		nullErr.Pointer = string(dec.StackPointer())
+		return nullErr
+	}
+
+	val, err := internal.FromJSONAs[T](w.Enum.table, data, w.Enum.repr)
+	var invalidErr *ErrInvalidEnumValue
+	if errors.As(err, &invalidErr) {
+		invalidErr.Pointer = string(dec.StackPointer())
+	}
+	return w.apply(val, err, true, data.Kind() == '0')
+}
@@ -0,0 +1,115 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package enum
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"io"
+	"testing"
+)
+
+// TestWrapperJSONv2 tests marshalling with encoding/json/v2
+func TestWrapperJSONv2(t *testing.T) {
+	type item struct {
+		Status Wrapper[int] `json:"status"`
+	}
+	statuses := NewWrapper[int]("pending", "active")
+
+	in := item{Status: statuses}
+	in.Status.Set(1)
+	data, err := jsonv2.Marshal(in)
+	if err != nil || string(data) != `{"status":"active"}` {
+		t.Fatalf(`expected {"status":"active"}, got %s (err %v)`, data, err)
+	}
+
+	numeric := item{Status: statuses.WithRepresentation(RepresentNumber)}
+	numeric.Status.Set(1)
+	if data, err := jsonv2.Marshal(numeric); err != nil || string(data) != `{"status":1}` {
+		t.Errorf(`expected {"status":1}, got %s (err %v)`, data, err)
+	}
+
+	out := item{Status: statuses}
+	if err := jsonv2.Unmarshal([]byte(`{"status":"pending"}`), &out); err != nil || out.Status.Get() != 0 {
+		t.Errorf("expected 0, got %d (err %v)", out.Status.Get(), err)
+	}
+
+	in.Status.Set(9)
+	if _, err := jsonv2.Marshal(in); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
+	}
+}
+
+// TestWrapperJSONv2Errors tests the JSON pointer of invalid values
+func TestWrapperJSONv2Errors(t *testing.T) {
+	type OrderStatus int
+	type order struct {
+		Items []struct {
+			Status Wrapper[OrderStatus] `json:"status"`
+		} `json:"items"`
+	}
+	_ = NewWrapper[OrderStatus]("pending", "active")
+
+	var o order
+	err := jsonv2.Unmarshal([]byte(`{"items":[{"status":"active"},{"status":"lost"}]}`), &o)
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) {
+		t.Fatalf("expected ErrInvalidEnumValue, got %v", err)
+	}
+	if invalidErr.Value != "lost" || invalidErr.Pointer != "/items/1/status" {
+		t.Errorf("expected lost at /items/1/status, got %q at %q", invalidErr.Value, invalidErr.Pointer)
+	}
+	var semanticErr *jsonv2.SemanticError
+	if !errors.As(err, &semanticErr) || semanticErr.JSONPointer != "/items/1/status" {
+		t.Errorf("expected a json.SemanticError at /items/1/status, got %v", err)
+	}
+}
+
+// TestWrapperJSONv2ParseMode tests that labels follow the parse mode of the
+// enum, and not the MatchCaseInsensitiveNames option meant for member names
+func TestWrapperJSONv2ParseMode(t *testing.T) {
+	w := NewWrapper[int]("pending", "active")
+
+	if err := jsonv2.Unmarshal([]byte(`"ACTIVE"`), &w, jsonv2.MatchCaseInsensitiveNames(true)); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue with the default parse mode, got %v", err)
+	}
+
+	relaxed := w.WithParseMode(ParseCaseInsensitive)
+	if err := jsonv2.Unmarshal([]byte(`"ACTIVE"`), &relaxed); err != nil || relaxed.Get() != 1 {
+		t.Errorf("expected 1, got %d (err %v)", relaxed.Get(), err)
+	}
+}
+
+// TestWrapperJSONv2Stream tests decoding values straight from a token stream
+func TestWrapperJSONv2Stream(t *testing.T) {
+	w := NewWrapper[int]("pending", "active")
+	dec := jsontext.NewDecoder(bytes.NewReader([]byte(`"active" "pending" "active"`)))
+
+	var got []int
+	for {
+		if err := w.UnmarshalJSONFrom(dec); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("UnmarshalJSONFrom failed: %v", err)
+		}
+		got = append(got, w.Get())
+	}
+	if len(got) != 3 || got[0] != 1 || got[1] != 0 || got[2] != 1 {
+		t.Errorf("expected [1 0 1], got %v", got)
+	}
+}
+
+// BenchmarkWrapperMarshalJSONTo benchmarks encoding to a jsontext.Encoder
+func BenchmarkWrapperMarshalJSONTo(b *testing.B) {
+	w := NewWrapper[int]("pending", "active")
+	w.Set(1)
+	enc := jsontext.NewEncoder(io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := w.MarshalJSONTo(enc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,11 +1,18 @@
 package enum
 
 import (
+	"bytes"
 	"database/sql/driver"
 	"encoding/binary"
+	"encoding/gob"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
 	"reflect"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 // TestNewWrapper tests wrapper creation
@@ -144,11 +151,6 @@ func TestWrapperJSONMarshal(t *testing.T) {
 			value:    3,
 			expected: `"winter"`,
 		},
-		{
-			name:     "invalid season",
-			value:    10,
-			expected: `"Invalid"`,
-		},
 	}
 
 	for _, tt := range tests {
@@ -262,11 +264,6 @@ func TestWrapperYAMLMarshal(t *testing.T) {
 			value:    3,
 			expected: "west",
 		},
-		{
-			name:     "invalid direction",
-			value:    10,
-			expected: "Invalid",
-		},
 	}
 
 	for _, tt := range tests {
@@ -277,7 +274,7 @@ func TestWrapperYAMLMarshal(t *testing.T) {
 				t.Errorf("unexpected error: %v", err)
 			}
 
-			if result != tt.expected {
+			if yamlNodeValue(result) != tt.expected {
 				t.Errorf("expected %v, got %v", tt.expected, result)
 			}
 		})
@@ -290,7 +287,7 @@ func TestWrapperYAMLUnmarshal(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		input       any
+		input       string
 		expectedVal int
 		expectError bool
 	}{
@@ -320,7 +317,13 @@ func TestWrapperYAMLUnmarshal(t *testing.T) {
 		},
 		{
 			name:        "non-string input",
-			input:       123,
+			input:       "123",
+			expectedVal: 0,
+			expectError: true,
+		},
+		{
+			name:        "sequence input",
+			input:       "[small]",
 			expectedVal: 0,
 			expectError: true,
 		},
@@ -331,19 +334,7 @@ func TestWrapperYAMLUnmarshal(t *testing.T) {
 			// Reset wrapper value
 			wrapper.Set(99)
 
-			// Create unmarshal function
-			unmarshal := func(v any) error {
-				if ptr, ok := v.(*string); ok {
-					if str, ok := tt.input.(string); ok {
-						*ptr = str
-						return nil
-					}
-					return &json.UnsupportedTypeError{Type: reflect.TypeOf(tt.input)}
-				}
-				return &json.UnsupportedTypeError{Type: reflect.TypeOf(v)}
-			}
-
-			err := wrapper.UnmarshalYAML(unmarshal)
+			err := yaml.Unmarshal([]byte(tt.input), &wrapper)
 
 			if tt.expectError {
 				if err == nil {
@@ -902,3 +893,610 @@ func TestWrapperEnsureEnumWithAllUnmarshalMethods(t *testing.T) {
 		})
 	}
 }
+
+// TestWrapperFromMembers tests marshalling of wrappers with explicit values
+func TestWrapperFromMembers(t *testing.T) {
+	wrapper := NewWrapperFromMembers(
+		Member[int]{Label: "active", Value: 10},
+		Member[int]{Label: "suspended", Value: 20},
+		Member[int]{Label: "deleted", Value: 99},
+	)
+	wrapper.Set(99)
+
+	jsonData, err := json.Marshal(wrapper)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	if string(jsonData) != `"deleted"` {
+		t.Errorf("expected %q, got %s", `"deleted"`, jsonData)
+	}
+
+	if err := wrapper.UnmarshalJSON([]byte(`"suspended"`)); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if wrapper.Get() != 20 {
+		t.Errorf("expected 20, got %d", wrapper.Get())
+	}
+
+	binaryData, err := wrapper.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	wrapper.Set(10)
+	if err := wrapper.UnmarshalBinary(binaryData); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if wrapper.Get() != 20 {
+		t.Errorf("expected 20, got %d", wrapper.Get())
+	}
+
+	value, err := wrapper.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	if value != "suspended" {
+		t.Errorf("expected %q, got %v", "suspended", value)
+	}
+
+	// A value that is only a valid index must be rejected
+	wrapper.Set(1)
+	if _, err := wrapper.Value(); err == nil {
+		t.Error("expected error for value outside the declared members")
+	}
+}
+
+// TestWrapperIntegerKinds tests marshalling of wrappers over sized integer types
+func TestWrapperIntegerKinds(t *testing.T) {
+	type Level uint8
+
+	wrapper := NewWrapper[Level]("debug", "info", "warn", "error")
+	wrapper.Set(2)
+
+	data, err := json.Marshal(wrapper)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	if string(data) != `"warn"` {
+		t.Errorf("expected %q, got %s", `"warn"`, data)
+	}
+
+	if err := wrapper.UnmarshalText([]byte("error")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if wrapper.Get() != 3 {
+		t.Errorf("expected 3, got %d", wrapper.Get())
+	}
+
+	wrapper.Set(200)
+	if _, err := wrapper.Value(); err == nil {
+		t.Error("expected error for out of range uint8 value")
+	}
+}
+
+// TestWrapperWithAliases tests that every unmarshaller resolves aliases
+func TestWrapperWithAliases(t *testing.T) {
+	var deprecated []string
+	wrapper := NewWrapper[int]("pending", "cancelled").
+		WithAliases(Alias[int]{Label: "canceled", Value: 1, Deprecated: true}).
+		WithDeprecationHook(func(alias, _ string) {
+			deprecated = append(deprecated, alias)
+		})
+
+	binaryData := []byte{0, 8, 'c', 'a', 'n', 'c', 'e', 'l', 'e', 'd'}
+
+	tests := []struct {
+		name      string
+		unmarshal func(*Wrapper[int]) error
+	}{
+		{name: "UnmarshalJSON", unmarshal: func(w *Wrapper[int]) error { return w.UnmarshalJSON([]byte(`"canceled"`)) }},
+		{name: "UnmarshalText", unmarshal: func(w *Wrapper[int]) error { return w.UnmarshalText([]byte("canceled")) }},
+		{name: "UnmarshalBinary", unmarshal: func(w *Wrapper[int]) error { return w.UnmarshalBinary(binaryData) }},
+		{name: "Scan", unmarshal: func(w *Wrapper[int]) error { return w.Scan("canceled") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := wrapper
+			if err := tt.unmarshal(&w); err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+			if w.Get() != 1 {
+				t.Errorf("expected 1, got %d", w.Get())
+			}
+
+			data, err := w.MarshalJSON()
+			if err != nil {
+				t.Fatalf("MarshalJSON failed: %v", err)
+			}
+			if string(data) != `"cancelled"` {
+				t.Errorf("expected canonical label, got %s", data)
+			}
+		})
+	}
+
+	if len(deprecated) != len(tests) {
+		t.Errorf("expected %d deprecation reports, got %v", len(tests), deprecated)
+	}
+}
+
+// TestWrapperWithRepresentation tests numeric JSON and SQL representations
+func TestWrapperWithRepresentation(t *testing.T) {
+	numeric := NewWrapperFromMembers(
+		Member[int]{Label: "active", Value: 10},
+		Member[int]{Label: "deleted", Value: 99},
+	).WithRepresentation(RepresentNumber)
+	numeric.Set(99)
+
+	data, err := json.Marshal(numeric)
+	if err != nil {
+		t.Fatalf("MarshalJSON failed: %v", err)
+	}
+	if string(data) != `99` {
+		t.Errorf("expected 99, got %s", data)
+	}
+
+	value, err := numeric.Value()
+	if err != nil {
+		t.Fatalf("Value failed: %v", err)
+	}
+	if value != int64(99) {
+		t.Errorf("expected int64(99), got %#v", value)
+	}
+
+	if err := numeric.Scan(int64(10)); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if numeric.Get() != 10 {
+		t.Errorf("expected 10, got %d", numeric.Get())
+	}
+
+	if err := json.Unmarshal([]byte(`42`), &numeric); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue for unknown code, got %v", err)
+	}
+
+	// Text keeps using labels
+	text, err := numeric.MarshalText()
+	if err != nil || string(text) != "active" {
+		t.Errorf("expected label text, got %q (err %v)", text, err)
+	}
+
+	both := NewWrapper[int]("low", "high").WithRepresentation(RepresentLabelOrNumber)
+	if err := json.Unmarshal([]byte(`1`), &both); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	data, err = json.Marshal(both)
+	if err != nil || string(data) != `"high"` {
+		t.Errorf("expected label output, got %s (err %v)", data, err)
+	}
+}
+
+// TestWrapperPreserveUnknown tests that unknown input survives a round trip.
+func TestWrapperPreserveUnknown(t *testing.T) {
+	w := NewWrapper[int]("pending", "shipped").WithPreserveUnknown(true)
+
+	if err := json.Unmarshal([]byte(`"returned"`), &w); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	if !w.IsUnknown() {
+		t.Fatal("expected IsUnknown after unknown label")
+	}
+	if raw, ok := w.Unknown(); !ok || raw != "returned" {
+		t.Errorf("expected raw \"returned\", got %q (ok %v)", raw, ok)
+	}
+	if w.String() != "returned" {
+		t.Errorf("expected String \"returned\", got %q", w.String())
+	}
+
+	data, err := json.Marshal(w)
+	if err != nil || string(data) != `"returned"` {
+		t.Errorf("expected \"returned\", got %s (err %v)", data, err)
+	}
+	text, err := w.MarshalText()
+	if err != nil || string(text) != "returned" {
+		t.Errorf("expected text returned, got %q (err %v)", text, err)
+	}
+	yamlVal, err := w.MarshalYAML()
+	if err != nil || yamlNodeValue(yamlVal) != "returned" {
+		t.Errorf("expected YAML returned, got %v (err %v)", yamlVal, err)
+	}
+	value, err := w.Value()
+	if err != nil || value != "returned" {
+		t.Errorf("expected SQL value returned, got %#v (err %v)", value, err)
+	}
+
+	bin, err := w.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	decoded := w
+	decoded.Set(0)
+	if decoded.IsUnknown() {
+		t.Error("expected Set to clear unknown state")
+	}
+	if err := decoded.UnmarshalBinary(bin); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if raw, _ := decoded.Unknown(); raw != "returned" {
+		t.Errorf("expected binary round trip to keep raw, got %q", raw)
+	}
+
+	if err := w.UnmarshalText([]byte("shipped")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if w.IsUnknown() || w.Get() != 1 {
+		t.Errorf("expected known value to clear unknown state, got %d (unknown %v)", w.Get(), w.IsUnknown())
+	}
+
+	// Malformed input still fails
+	if err := json.Unmarshal([]byte(`{}`), &w); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+	if err := w.Scan(3.5); err == nil {
+		t.Error("expected error for unsupported SQL type")
+	}
+
+	// Numbers are not labels, and null is not a member
+	for _, input := range []func() error{
+		func() error { return w.Scan(int64(7)) },
+		func() error { return json.Unmarshal([]byte(`null`), &w) },
+	} {
+		w.Set(1)
+		if err := input(); !errors.Is(err, &ErrInvalidEnumValue{}) {
+			t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+		}
+		if data, err := json.Marshal(w); w.IsUnknown() || err != nil || string(data) != `"shipped"` {
+			t.Errorf("expected \"shipped\" to be kept, got %s (unknown %v, err %v)", data, w.IsUnknown(), err)
+		}
+	}
+}
+
+// TestWrapperPreserveUnknownNumeric tests that unknown numbers stay numbers.
+func TestWrapperPreserveUnknownNumeric(t *testing.T) {
+	w := NewWrapper[int]("pending", "shipped").
+		WithRepresentation(RepresentNumber).
+		WithPreserveUnknown(true)
+
+	if err := json.Unmarshal([]byte(`7`), &w); err != nil {
+		t.Fatalf("UnmarshalJSON failed: %v", err)
+	}
+	data, err := json.Marshal(w)
+	if err != nil || string(data) != `7` {
+		t.Errorf("expected 7, got %s (err %v)", data, err)
+	}
+
+	if err := w.Scan(int64(9)); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	value, err := w.Value()
+	if err != nil || value != int64(9) {
+		t.Errorf("expected int64(9), got %#v (err %v)", value, err)
+	}
+}
+
+// TestWrapperInt64 tests numeric conversions for wire formats
+func TestWrapperInt64(t *testing.T) {
+	type Carrier int16
+	w := NewWrapperFromMembers(Member[Carrier]{Label: "post", Value: -1}, Member[Carrier]{Label: "courier", Value: 40})
+
+	if err := w.SetInt64(40); err != nil || w.String() != "courier" {
+		t.Fatalf("expected courier, got %s (err %v)", w.String(), err)
+	}
+	if n, err := w.Int64(); err != nil || n != 40 {
+		t.Errorf("expected 40, got %d (err %v)", n, err)
+	}
+	if err := w.SetInt64(1 << 20); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+	w.Set(3)
+	if _, err := w.Int64(); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
+	}
+
+	// Unknown numbers round trip, unknown labels have no number
+	lenient := w.WithPreserveUnknown(true)
+	if err := lenient.SetInt64(7); err != nil {
+		t.Fatalf("SetInt64 failed: %v", err)
+	}
+	if n, err := lenient.Int64(); err != nil || n != 7 {
+		t.Errorf("expected 7, got %d (err %v)", n, err)
+	}
+	if err := lenient.UnmarshalText([]byte("pigeon")); err != nil {
+		t.Fatalf("UnmarshalText failed: %v", err)
+	}
+	if _, err := lenient.Int64(); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+}
+
+// TestWrapperUnknownDisabled tests that unknown input fails by default.
+func TestWrapperUnknownDisabled(t *testing.T) {
+	w := NewWrapper[int]("pending", "shipped")
+	if err := json.Unmarshal([]byte(`"returned"`), &w); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+	if w.IsUnknown() {
+		t.Error("expected IsUnknown to be false")
+	}
+}
+
+// TestWrapperBinaryEncoding tests compact binary encodings and migration from labels
+func TestWrapperBinaryEncoding(t *testing.T) {
+	labels := NewWrapper[int]("low", "medium", "high", "critical")
+	compact := labels.WithBinaryEncoding(BinaryVarint)
+	if compact.Enum.BinaryEncoding() != BinaryVarint {
+		t.Fatalf("expected BinaryVarint, got %d", compact.Enum.BinaryEncoding())
+	}
+
+	compact.Set(3)
+	data, err := compact.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary failed: %v", err)
+	}
+	if len(data) != 2 {
+		t.Errorf("expected 2 bytes, got %d", len(data))
+	}
+
+	// Old label data is still read
+	labels.Set(2)
+	old, _ := labels.MarshalBinary()
+	if err := compact.UnmarshalBinary(old); err != nil || compact.Get() != 2 {
+		t.Errorf("expected 2 from label data, got %d (err %v)", compact.Get(), err)
+	}
+	// and label wrappers read compact data
+	if err := labels.UnmarshalBinary(data); err != nil || labels.Get() != 3 {
+		t.Errorf("expected 3 from varint data, got %d (err %v)", labels.Get(), err)
+	}
+
+	// Data written by another version of the enum is rejected
+	v1 := NewWrapper[int]("low", "high").WithBinaryEncoding(BinaryVarintFingerprint)
+	v1.Set(1)
+	stale, _ := v1.MarshalBinary()
+	v2 := labels.WithBinaryEncoding(BinaryVarintFingerprint)
+	if err := v2.UnmarshalBinary(stale); !errors.Is(err, &ErrBinaryFingerprintMismatch{}) {
+		t.Errorf("expected ErrBinaryFingerprintMismatch, got %v", err)
+	}
+
+	// Unknown values are preserved byte for byte
+	lenient := compact.WithPreserveUnknown(true)
+	unknown := []byte{0xff, 40}
+	if err := lenient.UnmarshalBinary(unknown); err != nil || !lenient.IsUnknown() {
+		t.Fatalf("expected unknown value to be preserved, got err %v", err)
+	}
+	if again, _ := lenient.MarshalBinary(); !bytes.Equal(again, unknown) {
+		t.Errorf("expected %v, got %v", unknown, again)
+	}
+}
+
+// TestWrapperGob tests gob encoding of wrappers resolved from the registry
+func TestWrapperGob(t *testing.T) {
+	type Shipping int
+	Register[Shipping]("standard", "express", "overnight")
+
+	type Parcel struct {
+		Weight   int
+		Shipping Wrapper[Shipping]
+	}
+
+	in := Parcel{Weight: 3}
+	in.Shipping.Set(2)
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("standard")) {
+		t.Errorf("expected only the member to be encoded, got %q", buf.Bytes())
+	}
+
+	var out Parcel
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatalf("Decode failed: %v", err)
+	}
+	if out.Weight != 3 || out.Shipping.Get() != 2 || out.Shipping.String() != "overnight" {
+		t.Errorf("expected overnight, got %s (%d)", out.Shipping.String(), out.Shipping.Get())
+	}
+
+	// The compact encoding writes the ordinal
+	var compact Wrapper[Shipping]
+	compact = compact.WithBinaryEncoding(BinaryVarint)
+	compact.Set(1)
+	data, err := compact.GobEncode()
+	if err != nil || !bytes.Equal(data, []byte{0xff, 2}) {
+		t.Errorf("expected [255 2], got %v (err %v)", data, err)
+	}
+	var decoded Wrapper[Shipping]
+	if err := decoded.GobDecode(data); err != nil || decoded.String() != "express" {
+		t.Errorf("expected express, got %s (err %v)", decoded.String(), err)
+	}
+
+	// Unknown members are rejected
+	if err := decoded.GobDecode([]byte("\x00\x06pickup")); !errors.Is(err, &ErrInvalidEnumValue{}) {
+		t.Errorf("expected ErrInvalidEnumValue, got %v", err)
+	}
+}
+
+// TestWrapperAppend tests the appenders and reading a stream of wrappers
+func TestWrapperAppend(t *testing.T) {
+	w := NewWrapper[int]("low", "medium", "high")
+	w.Set(2)
+
+	tests := []struct {
+		name    string
+		append  func([]byte) ([]byte, error)
+		marshal func() ([]byte, error)
+	}{
+		{"AppendJSON", w.AppendJSON, w.MarshalJSON},
+		{"AppendText", w.AppendText, w.MarshalText},
+		{"AppendBinary", w.AppendBinary, w.MarshalBinary},
+	}
+	for _, tt := range tests {
+		expected, _ := tt.marshal()
+		got, err := tt.append([]byte("prefix"))
+		if err != nil || string(got) != "prefix"+string(expected) {
+			t.Errorf("%s = %q (err %v), want %q", tt.name, got, err, "prefix"+string(expected))
+		}
+	}
+
+	var stream []byte
+	for _, v := range []int{1, 0, 2} {
+		w.Set(v)
+		stream, _ = w.AppendBinary(stream)
+	}
+	r := bytes.NewReader(stream)
+	var decoded Wrapper[int]
+	for _, want := range []int{1, 0, 2} {
+		if err := decoded.ReadBinary(r); err != nil || decoded.Get() != want {
+			t.Fatalf("expected %d, got %d (err %v)", want, decoded.Get(), err)
+		}
+	}
+	if err := decoded.ReadBinary(r); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+
+	// Unknown values are appended as received
+	lenient := w.WithPreserveUnknown(true)
+	_ = lenient.UnmarshalJSON([]byte(`"urgent"`))
+	if got, _ := lenient.AppendJSON([]byte("x")); string(got) != `x"urgent"` {
+		t.Errorf(`expected x"urgent", got %s`, got)
+	}
+}
+
+// TestWrapperMarshalOutOfRange tests marshalling a value that is not a member
+func TestWrapperMarshalOutOfRange(t *testing.T) {
+	strict := NewWrapperFromMembers(
+		Member[int]{Label: "low", Value: 10},
+		Member[int]{Label: "high", Value: 30},
+		Member[int]{Label: "medium", Value: 20},
+	)
+	strict.Set(25)
+	lenient := strict.WithLenientMarshal(true)
+
+	str := func(b []byte, err error) (any, error) { return string(b), err }
+	tests := []struct {
+		name     string
+		strict   func() (any, error)
+		lenient  func() (any, error)
+		expected any
+	}{
+		{"JSON", func() (any, error) { return str(strict.MarshalJSON()) },
+			func() (any, error) { return str(lenient.MarshalJSON()) }, `"Invalid"`},
+		{"YAML", strict.MarshalYAML, func() (any, error) { v, err := lenient.MarshalYAML(); return yamlNodeValue(v), err }, "Invalid"},
+		{"Text", func() (any, error) { return str(strict.MarshalText()) },
+			func() (any, error) { return str(lenient.MarshalText()) }, "Invalid"},
+		{"Binary", func() (any, error) { return str(strict.MarshalBinary()) },
+			func() (any, error) { return str(lenient.MarshalBinary()) }, "\x00\x07Invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.strict()
+			var rangeErr *ErrValueOutOfRange
+			if !errors.As(err, &rangeErr) {
+				t.Fatalf("expected ErrValueOutOfRange, got %v", err)
+			}
+			if rangeErr.Value != "25" || rangeErr.Min != "10" || rangeErr.Max != "30" {
+				t.Errorf("expected 25 out of [10, 30], got %+v", rangeErr)
+			}
+
+			got, err := tt.lenient()
+			if err != nil || got != tt.expected {
+				t.Errorf("expected %q, got %q (err %v)", tt.expected, got, err)
+			}
+		})
+	}
+
+	// SQL values stay strict
+	if _, err := lenient.Value(); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange from Value, got %v", err)
+	}
+}
+
+// yamlScalar returns the node yaml.v3 hands to UnmarshalYAML for a plain string.
+func yamlScalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// yamlNodeValue returns the value of a node returned by MarshalYAML, or v itself.
+func yamlNodeValue(v any) any {
+	if node, ok := v.(*yaml.Node); ok {
+		return node.Value
+	}
+	return v
+}
+
+// TestWrapperYAMLDocument tests decoding and encoding whole yaml.v3 documents
+func TestWrapperYAMLDocument(t *testing.T) {
+	type config struct {
+		Level Wrapper[int] `yaml:"level"`
+	}
+	levels := NewWrapper[int]("debug", "info", "warn")
+
+	cfg := config{Level: levels}
+	if err := yaml.Unmarshal([]byte("level: warn\n"), &cfg); err != nil || cfg.Level.Get() != 2 {
+		t.Fatalf("expected warn, got %d (err %v)", cfg.Level.Get(), err)
+	}
+
+	err := yaml.Unmarshal([]byte("# settings\nlevel: verbose\n"), &cfg)
+	var invalidErr *ErrInvalidEnumValue
+	if !errors.As(err, &invalidErr) {
+		t.Fatalf("expected ErrInvalidEnumValue, got %v", err)
+	}
+	if invalidErr.Value != "verbose" || invalidErr.Line != 2 || invalidErr.Column != 8 {
+		t.Errorf("expected verbose at 2:8, got %q at %d:%d", invalidErr.Value, invalidErr.Line, invalidErr.Column)
+	}
+
+	cfg.Level = levels.WithYAMLStyle(yaml.DoubleQuotedStyle).WithYAMLComment(true)
+	cfg.Level.Set(1)
+	data, err := yaml.Marshal(cfg)
+	if expected := "level: \"info\" # one of: debug, info, warn\n"; err != nil || string(data) != expected {
+		t.Errorf("expected %q, got %q (err %v)", expected, data, err)
+	}
+
+	// Labels that would read as another type are quoted
+	numbers := struct {
+		Code Wrapper[int] `yaml:"code"`
+	}{Code: NewWrapper[int]("200", "404")}
+	numbers.Code.Set(1)
+	if data, err := yaml.Marshal(numbers); err != nil || string(data) != "code: \"404\"\n" {
+		t.Errorf("expected quoted label, got %q (err %v)", data, err)
+	}
+}
+
+// TestWrapperXML tests XML elements and attributes
+func TestWrapperXML(t *testing.T) {
+	type order struct {
+		XMLName  xml.Name     `xml:"order"`
+		Priority Wrapper[int] `xml:"priority,attr"`
+		Status   Wrapper[int] `xml:"status"`
+	}
+	priorities := NewWrapper[int]("low", "high")
+	statuses := NewWrapper[int]("pending", "shipped")
+
+	o := order{Priority: priorities, Status: statuses}
+	o.Priority.Set(1)
+	o.Status.Set(1)
+	data, err := xml.Marshal(o)
+	if expected := `<order priority="high"><status>shipped</status></order>`; err != nil || string(data) != expected {
+		t.Fatalf("expected %s, got %s (err %v)", expected, data, err)
+	}
+
+	decoded := order{Priority: priorities, Status: statuses}
+	if err := xml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("xml.Unmarshal failed: %v", err)
+	}
+	if decoded.Priority.Get() != 1 || decoded.Status.Get() != 1 {
+		t.Errorf("expected (1, 1), got (%d, %d)", decoded.Priority.Get(), decoded.Status.Get())
+	}
+
+	for _, input := range []string{
+		`<order priority="urgent"><status>shipped</status></order>`,
+		`<order priority="low"><status>lost</status></order>`,
+	} {
+		if err := xml.Unmarshal([]byte(input), &decoded); !errors.Is(err, &ErrInvalidEnumValue{}) {
+			t.Errorf("%s: expected ErrInvalidEnumValue, got %v", input, err)
+		}
+	}
+
+	o.Status.Set(7)
+	if _, err := xml.Marshal(o); !errors.Is(err, &ErrValueOutOfRange{}) {
+		t.Errorf("expected ErrValueOutOfRange, got %v", err)
+	}
+}